@@ -11,6 +11,7 @@ import (
 // goverter:useZeroValueOnPointerInconsistency
 // goverter:ignoreUnexported
 // goverter:extend ExtV1JSONToRuntimeRawExtension
+// goverter:extend CopyStringMap
 // +k8s:deepcopy-gen=false
 type Converter interface {
 
@@ -31,9 +32,27 @@ type Converter interface {
 }
 
 // ExtV1JSONToRuntimeRawExtension converts an extv1.JSON into a
-// *runtime.RawExtension.
+// *runtime.RawExtension. Empty values convert to nil like ArgoCD returns them,
+// so that Helm sources without valuesObject are not reported as drifted.
 func ExtV1JSONToRuntimeRawExtension(in extv1.JSON) *runtime.RawExtension {
+	if len(in.Raw) == 0 {
+		return nil
+	}
 	return &runtime.RawExtension{
 		Raw: in.Raw,
 	}
 }
+
+// CopyStringMap copies a map of strings. Empty maps convert to nil like ArgoCD
+// returns them, so that Kustomize sources without common labels or
+// annotations are not reported as drifted.
+func CopyStringMap(in map[string]string) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
@@ -20,6 +20,7 @@ package v1alpha1
 
 import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -32,7 +33,20 @@ type ApplicationParameters struct {
 	Destination ApplicationDestination `json:"destination" protobuf:"bytes,2,name=destination"`
 	// Project is a reference to the project this application belongs to.
 	// The empty string means that application belongs to the 'default' project.
+	// Changing it moves the application. If ArgoCD rejects the move, e.g.
+	// because the project does not exist, the Application is not Ready with
+	// reason ProjectMismatch until it is moved.
+	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1.Project
+	// +crossplane:generate:reference:refFieldName=ProjectRef
+	// +crossplane:generate:reference:selectorFieldName=ProjectSelector
+	// +optional
 	Project string `json:"project" protobuf:"bytes,3,name=project"`
+	// ProjectRef is a reference to a Project used to set Project
+	// +optional
+	ProjectRef *xpv1.Reference `json:"projectRef,omitempty"`
+	// ProjectSelector selects a reference to a Project used to set Project
+	// +optional
+	ProjectSelector *xpv1.Selector `json:"projectSelector,omitempty"`
 	// SyncPolicy controls when and how a sync will be performed
 	SyncPolicy *SyncPolicy `json:"syncPolicy,omitempty" protobuf:"bytes,4,name=syncPolicy"`
 	// IgnoreDifferences is a list of resources and their fields which should be ignored during comparison
@@ -43,11 +57,36 @@ type ApplicationParameters struct {
 	// This should only be changed in exceptional circumstances.
 	// Setting to zero will store no history. This will reduce storage used.
 	// Increasing will increase the space used to store the history, so we do not recommend increasing it.
-	// Default is 10.
+	// Default is 10. An explicit 0 is sent to ArgoCD as is.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
 	RevisionHistoryLimit *int64 `json:"revisionHistoryLimit,omitempty" protobuf:"bytes,7,name=revisionHistoryLimit"`
 
-	// Sources is a reference to the location of the application's manifests or chart
+	// Sources is a reference to the location of the application's manifests or chart.
+	// Sources that only provide a ref may be listed in any order. The order of
+	// the other sources is significant.
 	Sources ApplicationSources `json:"sources,omitempty" protobuf:"bytes,8,opt,name=sources"`
+
+	// Labels are set on the ArgoCD Application resource. A label removed from
+	// them is removed from the application. Other labels of the application,
+	// like the ones set by other tools, are kept and ignored when comparing
+	// with the observed state. Labels owned by ArgoCD (argoproj.io domain) are
+	// ignored.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// AppNamespace is the namespace the ArgoCD Application resource lives in.
+	// Only needed when ArgoCD is configured to manage Applications in any namespace.
+	// Defaults to the namespace ArgoCD is installed in, as configured for the
+	// provider with --argocd-namespace.
+	// +optional
+	AppNamespace *string `json:"appNamespace,omitempty"`
+
+	// SyncAfterCreate triggers a sync of the application once it was created
+	// in ArgoCD. The provider does not wait for the sync to complete, and a
+	// sync ArgoCD rejects is only reported as a SyncAfterCreate warning event.
+	// +optional
+	SyncAfterCreate *bool `json:"syncAfterCreate,omitempty"`
 }
 
 // ResourceIgnoreDifferences contains resource filter and list of json paths which should be ignored during comparison with live state.
@@ -90,8 +129,10 @@ type ApplicationSource struct {
 	// Plugin holds config management plugin specific options
 	Plugin *ApplicationSourcePlugin `json:"plugin,omitempty" protobuf:"bytes,11,opt,name=plugin"`
 	// Chart is a Helm chart name, and must be specified for applications sourced from a Helm repo.
+	// Chart and Path must not both be set.
 	Chart *string `json:"chart,omitempty" protobuf:"bytes,12,opt,name=chart"`
 	// Ref is reference to another source within sources field. This field will not be used if used with a `source` tag.
+	// A source with only repoURL and ref provides value files to other sources and needs neither path nor chart.
 	Ref *string `json:"ref,omitempty" protobuf:"bytes,13,opt,name=ref"`
 }
 
@@ -129,16 +170,108 @@ type ConnectionState struct {
 	ModifiedAt *metav1.Time `json:"attemptedAt,omitempty"`
 }
 
+// ReasonProjectMismatch is used by the Application controller when the
+// observed project of the application differs from the desired one.
+const ReasonProjectMismatch xpv1.ConditionReason = "ProjectMismatch"
+
+// ProjectMismatch returns a condition indicating that the application is not
+// in its desired project, because ArgoCD rejected moving it.
+func ProjectMismatch(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonProjectMismatch,
+		Message:            msg,
+	}
+}
+
+// ReasonOwnedByApplicationSet is used by the Application controller when the
+// application is owned by an ApplicationSet and thus not managed.
+const ReasonOwnedByApplicationSet xpv1.ConditionReason = "OwnedByApplicationSet"
+
+// OwnedByApplicationSet returns a condition indicating that the application is
+// not managed, because it is owned by an ApplicationSet.
+func OwnedByApplicationSet(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOwnedByApplicationSet,
+		Message:            msg,
+	}
+}
+
+// AnnotationKeyManageApplicationSetOwned is the annotation of an Application
+// that, if set to "true", manages the application even if it is owned by an
+// ApplicationSet. The ApplicationSet controller reverts changes to the
+// applications it owns, so this is only useful if it does not, e.g. because
+// its sync policy is create-only.
+const AnnotationKeyManageApplicationSetOwned = "argocd.crossplane.io/manage-applicationset-owned"
+
+// AnnotationKeySkipValidation is the annotation of an Application that, if set
+// to "true", creates and updates the application without validation by ArgoCD,
+// e.g. to adopt an application whose repository is not permitted by its
+// project yet. The application carries a Warning condition while it is set.
+const AnnotationKeySkipValidation = "argocd.crossplane.io/skip-validation"
+
 // A ApplicationSpec defines the desired state of an ArgoCD Application.
 type ApplicationSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       ApplicationParameters `json:"forProvider"`
+	// ConnectionDetailsKeys renames the keys of the published connection
+	// details to the mapped keys, so that consumers need not know the keys
+	// of this provider. Keys that are not mapped are published as they are.
+	// +optional
+	ConnectionDetailsKeys map[string]string `json:"connectionDetailsKeys,omitempty"`
+}
+
+// GetConnectionDetailsKeys returns the keys the connection details of this
+// Application are published as.
+func (mg *Application) GetConnectionDetailsKeys() map[string]string {
+	return mg.Spec.ConnectionDetailsKeys
+}
+
+// AnnotationKeyTerminateOperation is the annotation of an Application that
+// terminates its running operation, e.g. a stuck sync. The operation is
+// terminated once per distinct value of the annotation, so that setting a new
+// value, like the current time, triggers it again.
+const AnnotationKeyTerminateOperation = "argocd.crossplane.io/terminate-operation"
+
+// Results of a termination of an operation.
+const (
+	// TerminationResultTerminated means that the running operation was
+	// terminated.
+	TerminationResultTerminated = "Terminated"
+	// TerminationResultNoOperation means that no operation was running, so
+	// there was nothing to terminate.
+	TerminationResultNoOperation = "NoOperation"
+)
+
+// OperationTermination records the last handled value of the
+// terminate-operation annotation.
+type OperationTermination struct {
+	// Trigger is the value of the annotation that was handled.
+	Trigger string `json:"trigger"`
+	// Result is Terminated or NoOperation.
+	Result string `json:"result"`
+	// HandledAt is the time the trigger was handled.
+	HandledAt metav1.Time `json:"handledAt"`
 }
 
 // A ApplicationStatus represents the observed state of an ArgoCD Application.
 type ApplicationStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          ArgoApplicationStatus `json:"atProvider,omitempty"`
+	// OperationTermination records the last termination of an operation
+	// triggered by the terminate-operation annotation.
+	// +optional
+	OperationTermination *OperationTermination `json:"operationTermination,omitempty"`
+	// ManagedLabels are the keys of the labels of the application that are
+	// managed by the provider, so that a label removed from the spec is
+	// removed from the application.
+	// +optional
+	ManagedLabels []string `json:"managedLabels,omitempty"`
 }
 
 // ApplicationSourceHelm holds helm specific options
@@ -251,7 +384,9 @@ type KustomizeImages []KustomizeImage
 // KustomizeImage represents a Kustomize image definition in the format [old_image_name=]<image_name>:<image_tag>
 type KustomizeImage string
 
-// Info is a list of informational items for this operation
+// Info is a list of informational items for this operation. Values starting
+// with http:// or https:// are rendered as links by ArgoCD and are reported as
+// a warning if they are no valid URL.
 type Info struct {
 	Name  string `json:"name" protobuf:"bytes,1,name=name"`
 	Value string `json:"value" protobuf:"bytes,2,name=value"`
@@ -266,7 +401,8 @@ type SyncPolicy struct {
 	Automated *SyncPolicyAutomated `json:"automated,omitempty" protobuf:"bytes,1,opt,name=automated"`
 	// Options allow you to specify whole app sync-options
 	SyncOptions SyncOptions `json:"syncOptions,omitempty" protobuf:"bytes,2,opt,name=syncOptions"`
-	// Retry controls failed sync retry behavior
+	// Retry controls failed sync retry behavior. It is independent of
+	// Automated and also applies to the sync started by SyncAfterCreate.
 	Retry *RetryStrategy `json:"retry,omitempty" protobuf:"bytes,3,opt,name=retry"`
 	// ManagedNamespaceMetadata controls metadata in the given namespace (if CreateNamespace=true)
 	ManagedNamespaceMetadata *ManagedNamespaceMetadata `json:"managedNamespaceMetadata,omitempty" protobuf:"bytes,4,opt,name=managedNamespaceMetadata"`
@@ -392,6 +528,9 @@ type OptionalArray struct {
 
 // ApplicationSourcePlugin holds options specific to config management plugins
 type ApplicationSourcePlugin struct {
+	// Name of the plugin. If omitted, ArgoCD discovers the plugin from the
+	// files of the source and passes Env and Parameters to it.
+	// +optional
 	Name       *string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
 	Env        `json:"env,omitempty" protobuf:"bytes,2,opt,name=env"`
 	Parameters ApplicationSourcePluginParameters `json:"parameters,omitempty" protobuf:"bytes,3,opt,name=parameters"`
@@ -320,18 +320,10 @@ func (c *ConverterImpl) pV1alpha1ApplicationSourceKustomizeToPV1alpha1Applicatio
 		pString2 := (*source).NameSuffix
 		v1alpha1ApplicationSourceKustomize.NameSuffix = &pString2
 		v1alpha1ApplicationSourceKustomize.Images = c.v1alpha1KustomizeImagesToV1alpha1KustomizeImages((*source).Images)
-		mapStringString := make(map[string]string, len((*source).CommonLabels))
-		for key, value := range (*source).CommonLabels {
-			mapStringString[key] = value
-		}
-		v1alpha1ApplicationSourceKustomize.CommonLabels = mapStringString
+		v1alpha1ApplicationSourceKustomize.CommonLabels = CopyStringMap((*source).CommonLabels)
 		pString3 := (*source).Version
 		v1alpha1ApplicationSourceKustomize.Version = &pString3
-		mapStringString2 := make(map[string]string, len((*source).CommonAnnotations))
-		for key2, value2 := range (*source).CommonAnnotations {
-			mapStringString2[key2] = value2
-		}
-		v1alpha1ApplicationSourceKustomize.CommonAnnotations = mapStringString2
+		v1alpha1ApplicationSourceKustomize.CommonAnnotations = CopyStringMap((*source).CommonAnnotations)
 		pBool := (*source).ForceCommonLabels
 		v1alpha1ApplicationSourceKustomize.ForceCommonLabels = &pBool
 		pBool2 := (*source).ForceCommonAnnotations
@@ -360,21 +352,13 @@ func (c *ConverterImpl) pV1alpha1ApplicationSourceKustomizeToPV1alpha1Applicatio
 		}
 		v1alpha1ApplicationSourceKustomize.NameSuffix = xstring2
 		v1alpha1ApplicationSourceKustomize.Images = c.v1alpha1KustomizeImagesToV1alpha1KustomizeImages2((*source).Images)
-		mapStringString := make(map[string]string, len((*source).CommonLabels))
-		for key, value := range (*source).CommonLabels {
-			mapStringString[key] = value
-		}
-		v1alpha1ApplicationSourceKustomize.CommonLabels = mapStringString
+		v1alpha1ApplicationSourceKustomize.CommonLabels = CopyStringMap((*source).CommonLabels)
 		var xstring3 string
 		if (*source).Version != nil {
 			xstring3 = *(*source).Version
 		}
 		v1alpha1ApplicationSourceKustomize.Version = xstring3
-		mapStringString2 := make(map[string]string, len((*source).CommonAnnotations))
-		for key2, value2 := range (*source).CommonAnnotations {
-			mapStringString2[key2] = value2
-		}
-		v1alpha1ApplicationSourceKustomize.CommonAnnotations = mapStringString2
+		v1alpha1ApplicationSourceKustomize.CommonAnnotations = CopyStringMap((*source).CommonAnnotations)
 		var xbool bool
 		if (*source).ForceCommonLabels != nil {
 			xbool = *(*source).ForceCommonLabels
@@ -555,16 +539,8 @@ func (c *ConverterImpl) pV1alpha1ManagedNamespaceMetadataToPV1alpha1ManagedNames
 	var pV1alpha1ManagedNamespaceMetadata *v1alpha1.ManagedNamespaceMetadata
 	if source != nil {
 		var v1alpha1ManagedNamespaceMetadata v1alpha1.ManagedNamespaceMetadata
-		mapStringString := make(map[string]string, len((*source).Labels))
-		for key, value := range (*source).Labels {
-			mapStringString[key] = value
-		}
-		v1alpha1ManagedNamespaceMetadata.Labels = mapStringString
-		mapStringString2 := make(map[string]string, len((*source).Annotations))
-		for key2, value2 := range (*source).Annotations {
-			mapStringString2[key2] = value2
-		}
-		v1alpha1ManagedNamespaceMetadata.Annotations = mapStringString2
+		v1alpha1ManagedNamespaceMetadata.Labels = CopyStringMap((*source).Labels)
+		v1alpha1ManagedNamespaceMetadata.Annotations = CopyStringMap((*source).Annotations)
 		pV1alpha1ManagedNamespaceMetadata = &v1alpha1ManagedNamespaceMetadata
 	}
 	return pV1alpha1ManagedNamespaceMetadata
@@ -622,11 +598,7 @@ func (c *ConverterImpl) pV1alpha1OptionalMapToPV1alpha1OptionalMap(source *v1alp
 	var pV1alpha1OptionalMap *OptionalMap
 	if source != nil {
 		var v1alpha1OptionalMap OptionalMap
-		mapStringString := make(map[string]string, len((*source).Map))
-		for key, value := range (*source).Map {
-			mapStringString[key] = value
-		}
-		v1alpha1OptionalMap.Map = mapStringString
+		v1alpha1OptionalMap.Map = CopyStringMap((*source).Map)
 		pV1alpha1OptionalMap = &v1alpha1OptionalMap
 	}
 	return pV1alpha1OptionalMap
@@ -635,11 +607,7 @@ func (c *ConverterImpl) pV1alpha1OptionalMapToPV1alpha1OptionalMap2(source *Opti
 	var pV1alpha1OptionalMap *v1alpha1.OptionalMap
 	if source != nil {
 		var v1alpha1OptionalMap v1alpha1.OptionalMap
-		mapStringString := make(map[string]string, len((*source).Map))
-		for key, value := range (*source).Map {
-			mapStringString[key] = value
-		}
-		v1alpha1OptionalMap.Map = mapStringString
+		v1alpha1OptionalMap.Map = CopyStringMap((*source).Map)
 		pV1alpha1OptionalMap = &v1alpha1OptionalMap
 	}
 	return pV1alpha1OptionalMap
@@ -153,6 +153,16 @@ func (in *ApplicationParameters) DeepCopyInto(out *ApplicationParameters) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Destination.DeepCopyInto(&out.Destination)
+	if in.ProjectRef != nil {
+		in, out := &in.ProjectRef, &out.ProjectRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProjectSelector != nil {
+		in, out := &in.ProjectSelector, &out.ProjectSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SyncPolicy != nil {
 		in, out := &in.SyncPolicy, &out.SyncPolicy
 		*out = new(SyncPolicy)
@@ -182,6 +192,23 @@ func (in *ApplicationParameters) DeepCopyInto(out *ApplicationParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AppNamespace != nil {
+		in, out := &in.AppNamespace, &out.AppNamespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.SyncAfterCreate != nil {
+		in, out := &in.SyncAfterCreate, &out.SyncAfterCreate
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationParameters.
@@ -573,6 +600,13 @@ func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ConnectionDetailsKeys != nil {
+		in, out := &in.ConnectionDetailsKeys, &out.ConnectionDetailsKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSpec.
@@ -590,6 +624,16 @@ func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.OperationTermination != nil {
+		in, out := &in.OperationTermination, &out.OperationTermination
+		*out = new(OperationTermination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagedLabels != nil {
+		in, out := &in.ManagedLabels, &out.ManagedLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationStatus.
@@ -1127,6 +1171,22 @@ func (in *OperationState) DeepCopy() *OperationState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationTermination) DeepCopyInto(out *OperationTermination) {
+	*out = *in
+	in.HandledAt.DeepCopyInto(&out.HandledAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationTermination.
+func (in *OperationTermination) DeepCopy() *OperationTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OptionalArray) DeepCopyInto(out *OptionalArray) {
 	*out = *in
@@ -21,8 +21,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	applicationv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	certificatesv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/certificates/v1alpha1"
 	clusterv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/cluster/v1alpha1"
+	gpgkeysv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/gpgkeys/v1alpha1"
 	projectsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	repocredsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/repocreds/v1alpha1"
 	repositoriesv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1"
 	v1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 )
@@ -32,6 +35,9 @@ func init() {
 	AddToSchemes = append(AddToSchemes,
 		v1alpha1.SchemeBuilder.AddToScheme,
 		repositoriesv1alpha1.SchemeBuilder.AddToScheme,
+		repocredsv1alpha1.SchemeBuilder.AddToScheme,
+		gpgkeysv1alpha1.SchemeBuilder.AddToScheme,
+		certificatesv1alpha1.SchemeBuilder.AddToScheme,
 		projectsv1alpha1.SchemeBuilder.AddToScheme,
 		clusterv1alpha1.SchemeBuilder.AddToScheme,
 		applicationv1alpha1.SchemeBuilder.AddToScheme,
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Certificate types supported by ArgoCD.
+const (
+	CertTypeSSH   = "ssh"
+	CertTypeHTTPS = "https"
+)
+
+// CertificateParameters define the desired state of a repository server
+// certificate in ArgoCD, either a TLS certificate or an SSH known host key.
+type CertificateParameters struct {
+	// ServerName is the DNS name of the repository server, e.g. github.com.
+	// SSH known host entries may have a port, e.g. [ssh.example.com]:2222
+	// +immutable
+	ServerName string `json:"serverName"`
+	// CertType is the type of the certificate, "https" for TLS certificates
+	// or "ssh" for SSH known host keys
+	// +kubebuilder:validation:Enum=https;ssh
+	// +immutable
+	CertType string `json:"certType"`
+	// CertSubType is the type of an SSH key, e.g. ssh-ed25519. It is
+	// required for SSH known host keys and ignored for TLS certificates.
+	// +optional
+	// +immutable
+	CertSubType *string `json:"certSubType,omitempty"`
+	// CertData is one or more PEM encoded TLS certificates, or the base64
+	// encoded SSH key as found in a known_hosts entry
+	CertData string `json:"certData"`
+}
+
+// CertificateObservation represents a repository server certificate in
+// ArgoCD.
+type CertificateObservation struct {
+	// CertSubTypes are the sub types of the certificates, e.g. the key
+	// algorithm of a TLS certificate
+	// +optional
+	CertSubTypes []string `json:"certSubTypes,omitempty"`
+	// CertInfo holds the SHA256 fingerprint of an SSH key, or the subjects of
+	// the TLS certificates
+	// +optional
+	CertInfo []string `json:"certInfo,omitempty"`
+}
+
+// A CertificateSpec defines the desired state of a repository server
+// certificate in ArgoCD.
+type CertificateSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CertificateParameters `json:"forProvider"`
+}
+
+// A CertificateStatus represents the observed state of a repository server
+// certificate in ArgoCD.
+type CertificateStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CertificateObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Certificate is a managed resource that represents a TLS certificate or an
+// SSH known host key ArgoCD trusts for a repository server.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SERVER",type="string",JSONPath=".spec.forProvider.serverName"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.forProvider.certType"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateSpec   `json:"spec"`
+	Status CertificateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CertificateList contains a list of Certificate items
+type CertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Certificate `json:"items"`
+}
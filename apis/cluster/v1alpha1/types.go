@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -41,10 +42,12 @@ type ClusterParameters struct {
 	// Reference between project and cluster that allow you automatically to be added as item inside Destinations project entity
 	// +optional
 	Project *string `json:"project,omitempty"`
-	// Labels for cluster secret metadata
+	// Labels for cluster secret metadata. Labels set by ArgoCD itself, like
+	// argocd.argoproj.io/secret-type, are ignored.
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
-	// Annotations for cluster secret metadata
+	// Annotations for cluster secret metadata. Annotations set by ArgoCD or
+	// kubectl, like argocd.argoproj.io/refresh, are ignored.
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
@@ -199,6 +202,12 @@ type ClusterObservation struct {
 	// Kubeconfig tracks changes to a Kubeconfig secret
 	// +optional
 	Kubeconfig *KubeconfigObservation `json:"kubeconfig,omitempty"`
+	// BearerTokenHash is the SHA-256 hash of the bearer token last written to
+	// ArgoCD, or of the token when the cluster was first observed. ArgoCD
+	// redacts the token on read, so the hash is used to detect a rotated
+	// token.
+	// +optional
+	BearerTokenHash string `json:"bearerTokenHash,omitempty"`
 }
 
 // A ClusterSpec defines the desired state of an ArgoCD Cluster.
@@ -237,3 +246,21 @@ type ClusterList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Cluster `json:"items"`
 }
+
+// ReasonMetadataPending is used by the Cluster controller when the labels or
+// annotations of the cluster are not applied in ArgoCD yet.
+const ReasonMetadataPending xpv1.ConditionReason = "MetadataPending"
+
+// MetadataPending returns a condition indicating that the cluster is
+// registered, but that its labels or annotations differ from the desired ones.
+// ApplicationSet cluster generators select clusters by their metadata, so the
+// cluster is not ready until they are applied.
+func MetadataPending(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMetadataPending,
+		Message:            msg,
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// GPGKeyParameters define the desired state of a GnuPG public key in ArgoCD.
+// ArgoCD verifies the signatures of commits with the keys it knows.
+type GPGKeyParameters struct {
+	// PublicKey is the ASCII-armored GnuPG public key. It must contain a
+	// single key.
+	// +immutable
+	PublicKey string `json:"publicKey"`
+}
+
+// GPGKeyObservation represents a GnuPG public key in ArgoCD.
+type GPGKeyObservation struct {
+	// KeyID specifies the key ID, in hexadecimal string format
+	KeyID string `json:"keyID,omitempty"`
+	// Fingerprint is the fingerprint of the key
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Owner holds the owner identification, e.g. a name and e-mail address
+	Owner string `json:"owner,omitempty"`
+	// Trust holds the level of trust assigned to this key
+	Trust string `json:"trust,omitempty"`
+	// SubType holds the key's sub type (e.g. rsa4096)
+	SubType string `json:"subType,omitempty"`
+}
+
+// A GPGKeySpec defines the desired state of a GnuPG public key in ArgoCD.
+type GPGKeySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       GPGKeyParameters `json:"forProvider"`
+}
+
+// A GPGKeyStatus represents the observed state of a GnuPG public key in
+// ArgoCD.
+type GPGKeyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          GPGKeyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A GPGKey is a managed resource that represents a GnuPG public key in
+// ArgoCD. Its external name is the key ID computed by ArgoCD.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="KEY-ID",type="string",JSONPath=".status.atProvider.keyID"
+// +kubebuilder:printcolumn:name="OWNER",type="string",JSONPath=".status.atProvider.owner"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
+type GPGKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GPGKeySpec   `json:"spec"`
+	Status GPGKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GPGKeyList contains a list of GPGKey items
+type GPGKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GPGKey `json:"items"`
+}
@@ -19,60 +19,113 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
-// ProjectParameters define the desired state of an ArgoCD Git Project
+// ProjectParameters define the desired state of an ArgoCD Git Project.
+// Optional fields that are unset when the Project is first observed are
+// initialized from the AppProject. Removing a field afterwards clears it in
+// ArgoCD. An empty list is never initialized, so it clears the field in
+// ArgoCD from the start.
 type ProjectParameters struct {
 	// SourceRepos contains list of repository URLs which can be used for deployment
 	// +crossplane:generate:reference:type=github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1.Repository
 	// +crossplane:generate:reference:refFieldName=SourceReposRefs
 	// +crossplane:generate:reference:selectorFieldName=SourceReposSelector
 	// +optional
-	SourceRepos []string `json:"sourceRepos,omitempty"`
+	SourceRepos []string `json:"sourceRepos"`
 	// SourceReposRefs is a reference to an array of Repository used to set SourceRepos
 	// +optional
 	SourceReposRefs []xpv1.Reference `json:"sourceReposRefs,omitempty"`
 	// SourceReposSelector selects references to Repositories used to set SourceRepos
 	// +optional
 	SourceReposSelector *xpv1.Selector `json:"sourceReposSelector,omitempty"`
-	// Destinations contains list of destinations available for deployment
+	// PreserveSourceReposOrder reports a different order of the SourceRepos
+	// as drift, e.g. for tooling that relies on the first matching
+	// repository. Duplicates are ignored either way, and so is the order if
+	// the SourceRepos contain the * wildcard. By default the order is ignored.
 	// +optional
-	Destinations []ApplicationDestination `json:"destinations,omitempty"`
+	PreserveSourceReposOrder *bool `json:"preserveSourceReposOrder,omitempty"`
+	// Destinations contains list of destinations available for deployment.
+	// A server, name or namespace prefixed with ! denies the destinations
+	// it matches, e.g. a namespace !kube-system.
+	// +optional
+	Destinations []ApplicationDestination `json:"destinations"`
 	// Description contains optional project description
 	// +optional
 	Description *string `json:"description,omitempty"`
-	// Roles are user defined RBAC roles associated with this project
+	// Roles are user defined RBAC roles associated with this project.
+	// If unset, the roles of the project are left unmanaged. An empty list
+	// removes all roles, which revokes their tokens.
 	// +optional
-	Roles []ProjectRole `json:"roles,omitempty"`
+	Roles []ProjectRole `json:"roles"`
 	// ClusterResourceWhitelist contains list of whitelisted cluster level resources
 	// +optional
-	ClusterResourceWhitelist []metav1.GroupKind `json:"clusterResourceWhitelist,omitempty"`
+	ClusterResourceWhitelist []metav1.GroupKind `json:"clusterResourceWhitelist"`
+	// ClusterResourceWhitelistFrom adds the resources listed in ConfigMaps to
+	// the ClusterResourceWhitelist, e.g. to share an organization wide
+	// policy. They follow the inline resources in the order of the ConfigMaps,
+	// and resources that are already listed are skipped.
+	// +optional
+	ClusterResourceWhitelistFrom []GroupKindsConfigMapKeySelector `json:"clusterResourceWhitelistFrom,omitempty"`
 	// NamespaceResourceBlacklist contains list of blacklisted namespace level resources
 	// +optional
-	NamespaceResourceBlacklist []metav1.GroupKind `json:"namespaceResourceBlacklist,omitempty"`
+	NamespaceResourceBlacklist []metav1.GroupKind `json:"namespaceResourceBlacklist"`
 	// OrphanedResources specifies if controller should monitor orphaned resources of apps in this project
 	// +optional
 	OrphanedResources *OrphanedResourcesMonitorSettings `json:"orphanedResources,omitempty"`
-	// SyncWindows controls when syncs can be run for apps in this project
+	// SyncWindows controls when syncs can be run for apps in this project.
+	// If unset, the sync windows of the project are left unmanaged. An empty
+	// list removes all sync windows.
 	// +optional
-	SyncWindows SyncWindows `json:"syncWindows,omitempty"`
+	SyncWindows SyncWindows `json:"syncWindows"`
 	// NamespaceResourceWhitelist contains list of whitelisted namespace level resources
 	// +optional
-	NamespaceResourceWhitelist []metav1.GroupKind `json:"namespaceResourceWhitelist,omitempty"`
+	NamespaceResourceWhitelist []metav1.GroupKind `json:"namespaceResourceWhitelist"`
+	// NamespaceResourceWhitelistFrom adds the resources listed in ConfigMaps
+	// to the NamespaceResourceWhitelist, like ClusterResourceWhitelistFrom.
+	// +optional
+	NamespaceResourceWhitelistFrom []GroupKindsConfigMapKeySelector `json:"namespaceResourceWhitelistFrom,omitempty"`
 	// SignatureKeys contains a list of PGP key IDs that commits in Git must be signed with in order to be allowed for sync
 	// +optional
-	SignatureKeys []SignatureKey `json:"signatureKeys,omitempty"`
+	SignatureKeys []SignatureKey `json:"signatureKeys"`
 	// ClusterResourceBlacklist contains list of blacklisted cluster level resources
 	// +optional
-	ClusterResourceBlacklist []metav1.GroupKind `json:"clusterResourceBlacklist,omitempty"`
+	ClusterResourceBlacklist []metav1.GroupKind `json:"clusterResourceBlacklist"`
 	// ProjectLabels labels that will be applied to the AppProject
 	// +optional
 	ProjectLabels map[string]string `json:"projectLabels,omitempty"`
+	// ManagedFields lists the fields of the AppProject that are managed by
+	// this Project, e.g. roles and sourceRepos if another tool manages the
+	// rest. Fields that are not listed keep the values set in ArgoCD and are
+	// never reported as drifted. All fields are managed if unset. A partially
+	// managed Project is not late initialized.
+	// +optional
+	ManagedFields []ProjectField `json:"managedFields,omitempty"`
 }
 
+// A ProjectField is a field of an AppProject that can be managed.
+// +kubebuilder:validation:Enum=sourceRepos;destinations;description;roles;clusterResourceWhitelist;namespaceResourceBlacklist;orphanedResources;syncWindows;namespaceResourceWhitelist;signatureKeys;clusterResourceBlacklist
+type ProjectField string
+
+// Fields of an AppProject.
+const (
+	ProjectFieldSourceRepos                ProjectField = "sourceRepos"
+	ProjectFieldDestinations               ProjectField = "destinations"
+	ProjectFieldDescription                ProjectField = "description"
+	ProjectFieldRoles                      ProjectField = "roles"
+	ProjectFieldClusterResourceWhitelist   ProjectField = "clusterResourceWhitelist"
+	ProjectFieldNamespaceResourceBlacklist ProjectField = "namespaceResourceBlacklist"
+	ProjectFieldOrphanedResources          ProjectField = "orphanedResources"
+	ProjectFieldSyncWindows                ProjectField = "syncWindows"
+	ProjectFieldNamespaceResourceWhitelist ProjectField = "namespaceResourceWhitelist"
+	ProjectFieldSignatureKeys              ProjectField = "signatureKeys"
+	ProjectFieldClusterResourceBlacklist   ProjectField = "clusterResourceBlacklist"
+)
+
 // ApplicationDestination holds information about the application's destination
 type ApplicationDestination struct {
 	// Server specifies the URL of the target cluster and must be set to the Kubernetes control plane API
@@ -104,7 +157,9 @@ type ProjectRole struct {
 	// Description is a description of the role
 	// +optional
 	Description *string `json:"description,omitempty"`
-	// Policies Stores a list of casbin formated strings that define access policies for the role in the project
+	// Policies Stores a list of casbin formated strings that define access policies for the role in the project.
+	// The subject must be proj:<project>:<role> and the object must be in the project. An object
+	// without a project, like *, is scoped to the project.
 	// +optional
 	Policies []string `json:"policies,omitempty"`
 	// JWTTokens are a list of generated JWT tokens bound to this role
@@ -117,11 +172,32 @@ type ProjectRole struct {
 
 // JWTToken holds the issuedAt and expiresAt values of a token
 type JWTToken struct {
-	IssuedAt int64 `json:"iat"`
+	// +optional
+	IssuedAt int64 `json:"iat,omitempty"`
 	// +optional
 	ExpiresAt *int64 `json:"exp,omitempty"`
 	// +optional
 	ID *string `json:"id,omitempty"`
+	// Name declares a token that is created by the provider if the role has
+	// no token with this name yet. The name is used as token ID, so that
+	// reconciling again never creates a second token. The token is published
+	// to the connection secret as <role>.<name>. IssuedAt, ExpiresAt and ID
+	// are ignored for named tokens.
+	// +optional
+	Name *string `json:"name,omitempty"`
+	// ExpiresIn is the lifetime of a named token created by the provider,
+	// e.g. 720h. An expired token is replaced by a new one. Default: the token
+	// does not expire.
+	// +optional
+	ExpiresIn *metav1.Duration `json:"expiresIn,omitempty"`
+	// Count is the number of tokens kept for a named token, e.g. 2 so that
+	// clients can switch to a new token while the old one is still valid.
+	// The tokens get the IDs <name>-<index> and are published to the
+	// connection secret as <role>.<name>.<index>, for index 0 to count-1.
+	// Default: a single token with the ID <name>.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Count *int32 `json:"count,omitempty"`
 }
 
 // JWTTokens represents a list of JWT tokens
@@ -176,6 +252,21 @@ type SyncWindow struct {
 	// ManualSync enables manual syncs when they would otherwise be blocked
 	// +optional
 	ManualSync *bool `json:"manualSync,omitempty"`
+	// TimeZone is the IANA time zone the schedule is evaluated in, e.g.
+	// Europe/Berlin. ArgoCD uses the time zone of its server if unset.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
+// A GroupKindsConfigMapKeySelector selects a key of a ConfigMap whose value
+// is a YAML list of resources, each with a group and a kind.
+type GroupKindsConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+	// Key of the list in the ConfigMap.
+	Key string `json:"key"`
 }
 
 // SignatureKey is the specification of a key required to verify commit signatures with
@@ -189,12 +280,61 @@ type ProjectObservation struct {
 	// JWTTokensByRole contains a list of JWT tokens issued for a given role
 	// +optional
 	JWTTokensByRole map[string]JWTTokens `json:"jwtTokensByRole,omitempty"`
+	// JWTTokenIDsByName maps the named tokens of the roles, keyed by
+	// <role>/<name>, to the IDs of the tokens in ArgoCD.
+	// +optional
+	JWTTokenIDsByName map[string]string `json:"jwtTokenIDsByName,omitempty"`
 }
 
 // A ProjectSpec defines the desired state of an ArgoCD Project.
 type ProjectSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       ProjectParameters `json:"forProvider"`
+	// ConnectionDetailsKeys renames the keys of the published connection
+	// details to the mapped keys, so that consumers need not know the keys
+	// of this provider. Keys that are not mapped are published as they are.
+	// +optional
+	ConnectionDetailsKeys map[string]string `json:"connectionDetailsKeys,omitempty"`
+}
+
+// GetConnectionDetailsKeys returns the keys the connection details of this
+// Project are published as.
+func (mg *Project) GetConnectionDetailsKeys() map[string]string {
+	return mg.Spec.ConnectionDetailsKeys
+}
+
+// TypeTokens indicates whether the named tokens of the project roles were
+// created.
+const TypeTokens xpv1.ConditionType = "Tokens"
+
+// Reasons of the Tokens condition.
+const (
+	ReasonTokensPending xpv1.ConditionReason = "TokensPending"
+	ReasonTokensCreated xpv1.ConditionReason = "TokensCreated"
+)
+
+// TokensPending returns a condition indicating that the project was updated,
+// but some of its named tokens could not be created yet. They are created by
+// the next reconcile.
+func TokensPending(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTokens,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTokensPending,
+		Message:            msg,
+	}
+}
+
+// TokensCreated returns a condition indicating that all named tokens of the
+// project roles were created.
+func TokensCreated() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeTokens,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTokensCreated,
+	}
 }
 
 // A ProjectStatus represents the observed state of an ArgoCD Project.
@@ -67,6 +67,21 @@ func (in *ApplicationDestination) DeepCopy() *ApplicationDestination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupKindsConfigMapKeySelector) DeepCopyInto(out *GroupKindsConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupKindsConfigMapKeySelector.
+func (in *GroupKindsConfigMapKeySelector) DeepCopy() *GroupKindsConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupKindsConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JWTToken) DeepCopyInto(out *JWTToken) {
 	*out = *in
@@ -80,6 +95,21 @@ func (in *JWTToken) DeepCopyInto(out *JWTToken) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpiresIn != nil {
+		in, out := &in.ExpiresIn, &out.ExpiresIn
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTToken.
@@ -240,6 +270,13 @@ func (in *ProjectObservation) DeepCopyInto(out *ProjectObservation) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.JWTTokenIDsByName != nil {
+		in, out := &in.JWTTokenIDsByName, &out.JWTTokenIDsByName
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectObservation.
@@ -272,6 +309,11 @@ func (in *ProjectParameters) DeepCopyInto(out *ProjectParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PreserveSourceReposOrder != nil {
+		in, out := &in.PreserveSourceReposOrder, &out.PreserveSourceReposOrder
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Destinations != nil {
 		in, out := &in.Destinations, &out.Destinations
 		*out = make([]ApplicationDestination, len(*in))
@@ -296,6 +338,11 @@ func (in *ProjectParameters) DeepCopyInto(out *ProjectParameters) {
 		*out = make([]metav1.GroupKind, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterResourceWhitelistFrom != nil {
+		in, out := &in.ClusterResourceWhitelistFrom, &out.ClusterResourceWhitelistFrom
+		*out = make([]GroupKindsConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
 	if in.NamespaceResourceBlacklist != nil {
 		in, out := &in.NamespaceResourceBlacklist, &out.NamespaceResourceBlacklist
 		*out = make([]metav1.GroupKind, len(*in))
@@ -318,6 +365,11 @@ func (in *ProjectParameters) DeepCopyInto(out *ProjectParameters) {
 		*out = make([]metav1.GroupKind, len(*in))
 		copy(*out, *in)
 	}
+	if in.NamespaceResourceWhitelistFrom != nil {
+		in, out := &in.NamespaceResourceWhitelistFrom, &out.NamespaceResourceWhitelistFrom
+		*out = make([]GroupKindsConfigMapKeySelector, len(*in))
+		copy(*out, *in)
+	}
 	if in.SignatureKeys != nil {
 		in, out := &in.SignatureKeys, &out.SignatureKeys
 		*out = make([]SignatureKey, len(*in))
@@ -335,6 +387,11 @@ func (in *ProjectParameters) DeepCopyInto(out *ProjectParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.ManagedFields != nil {
+		in, out := &in.ManagedFields, &out.ManagedFields
+		*out = make([]ProjectField, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectParameters.
@@ -389,6 +446,13 @@ func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ConnectionDetailsKeys != nil {
+		in, out := &in.ConnectionDetailsKeys, &out.ConnectionDetailsKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
@@ -471,6 +535,11 @@ func (in *SyncWindow) DeepCopyInto(out *SyncWindow) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncWindow.
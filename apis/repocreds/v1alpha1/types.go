@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RepositoryCredentialsParameters define the desired state of an ArgoCD
+// repository credential template. ArgoCD uses it for every repository whose
+// URL starts with the URL of the template and that has no credentials of its
+// own.
+type RepositoryCredentialsParameters struct {
+	// URL prefix of the repositories the credentials are used for, e.g.
+	// https://github.com/example-org/
+	// +immutable
+	URL string `json:"url"`
+	// Type of the repositories, maybe "git" or "helm", "git" is assumed if
+	// empty or absent
+	// +optional
+	Type *string `json:"type,omitempty"`
+	// Username for authenticating at the repo server
+	// +optional
+	Username *string `json:"username,omitempty"`
+	// Password for authenticating at the repo server
+	// +optional
+	PasswordRef *SecretReference `json:"passwordRef,omitempty"`
+	// SSH private key data for authenticating at the repo server
+	// only for Git repos
+	// +optional
+	SSHPrivateKeyRef *SecretReference `json:"sshPrivateKeyRef,omitempty"`
+	// TLS client cert data for authenticating at the repo server
+	// +optional
+	TLSClientCertDataRef *SecretReference `json:"tlsClientCertDataRef,omitempty"`
+	// TLS client cert key for authenticating at the repo server
+	// +optional
+	TLSClientCertKeyRef *SecretReference `json:"tlsClientCertKeyRef,omitempty"`
+	// Whether helm-oci support should be enabled for the repos
+	// +optional
+	EnableOCI *bool `json:"enableOCI,omitempty"`
+}
+
+// SecretReference holds the reference to a Kubernetes secret
+type SecretReference struct {
+	// Name of the secret.
+	Name string `json:"name"`
+
+	// Namespace of the secret.
+	Namespace string `json:"namespace"`
+
+	// Key whose value will be used.
+	Key string `json:"key"`
+}
+
+// RepositoryCredentialsObservation represents an argocd repository
+// credential template.
+type RepositoryCredentialsObservation struct {
+	// ConfigHash is a hash of the parameters and of the versions of the
+	// referenced secrets last written to ArgoCD. ArgoCD does not return the
+	// credentials, so the hash is used to detect that they changed.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+}
+
+// A RepositoryCredentialsSpec defines the desired state of an ArgoCD
+// repository credential template.
+type RepositoryCredentialsSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RepositoryCredentialsParameters `json:"forProvider"`
+}
+
+// A RepositoryCredentialsStatus represents the observed state of an ArgoCD
+// repository credential template.
+type RepositoryCredentialsStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RepositoryCredentialsObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RepositoryCredentials is a managed resource that represents an ArgoCD
+// repository credential template
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.forProvider.url"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,argocd}
+type RepositoryCredentials struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositoryCredentialsSpec   `json:"spec"`
+	Status RepositoryCredentialsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryCredentialsList contains a list of RepositoryCredentials items
+type RepositoryCredentialsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RepositoryCredentials `json:"items"`
+}
@@ -56,6 +56,10 @@ type RepositoryParameters struct {
 	// only for Helm repos
 	// +optional
 	Name *string `json:"name,omitempty"`
+	// Project scopes the repo to a project, so that only its members can
+	// manage it. The repo is global if unset.
+	// +optional
+	Project *string `json:"project,omitempty"`
 	// Whether credentials were inherited from a credential set
 	// +optional
 	InheritedCreds *bool `json:"inheritedCreds,omitempty"`
@@ -167,6 +167,11 @@ func (in *RepositoryParameters) DeepCopyInto(out *RepositoryParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Project != nil {
+		in, out := &in.Project, &out.Project
+		*out = new(string)
+		**out = **in
+	}
 	if in.InheritedCreds != nil {
 		in, out := &in.InheritedCreds, &out.InheritedCreds
 		*out = new(bool)
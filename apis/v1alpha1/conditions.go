@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types shared by all argocd managed resources.
+const (
+	// TypeWarning resources carry non-fatal warnings. ArgoCD accepts the
+	// resource, but its configuration deserves attention.
+	TypeWarning xpv1.ConditionType = "Warning"
+)
+
+// Condition reasons shared by all argocd managed resources.
+const (
+	ReasonMaintenance           xpv1.ConditionReason = "ArgoCDMaintenance"
+	ReasonProviderConfigMissing xpv1.ConditionReason = "ProviderConfigMissing"
+	ReasonValidationWarning     xpv1.ConditionReason = "ValidationWarning"
+	ReasonNoWarnings            xpv1.ConditionReason = "NoWarnings"
+)
+
+// Maintenance returns a condition indicating that the ArgoCD API answered
+// with 503 Service Unavailable, e.g. because ArgoCD is being upgraded. The
+// resource is reconciled again once ArgoCD recovers.
+func Maintenance() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMaintenance,
+		Message:            "ArgoCD answered 503 Service Unavailable, possibly read-only for maintenance or an upgrade",
+	}
+}
+
+// ProviderConfigMissing returns a condition indicating that the ProviderConfig
+// referenced by the resource does not exist, e.g. because it was deleted.
+func ProviderConfigMissing(name string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonProviderConfigMissing,
+		Message:            "ProviderConfig " + name + " does not exist",
+	}
+}
+
+// ValidationWarning returns a condition indicating that ArgoCD accepts the
+// resource, but that its configuration deserves attention.
+func ValidationWarning(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeWarning,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonValidationWarning,
+		Message:            msg,
+	}
+}
+
+// NoWarnings returns a condition indicating that no warnings are reported for
+// the resource.
+func NoWarnings() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeWarning,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoWarnings,
+	}
+}
@@ -24,8 +24,10 @@ import (
 
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
 type ProviderConfigSpec struct {
-	// ServerAddr is the hostname or IP of the argocd instance
-	ServerAddr string `json:"serverAddr"`
+	// ServerAddr is the hostname or IP of the argocd instance. Required
+	// unless the credentials source is ConnectionSecret.
+	// +optional
+	ServerAddr string `json:"serverAddr,omitempty"`
 
 	// PlainText specifies whether to use http vs https. Default: false.
 	// +optional
@@ -43,17 +45,94 @@ type ProviderConfigSpec struct {
 	// +optional
 	GRPCWebRootPath *string `json:"grpcWebRootPath,omitempty"`
 
+	// DialTimeout limits how long connecting to the argocd server may take,
+	// e.g. 10s. Unlike call timeouts it only applies to establishing the
+	// connection, so that an unreachable server fails fast. Default: no limit.
+	// +optional
+	DialTimeout *metav1.Duration `json:"dialTimeout,omitempty"`
+
+	// CACertificate references a PEM bundle of the CAs that issued the
+	// certificate of the argocd server, e.g. a private CA. It is trusted in
+	// addition to the CA bundle of the provider and the CA of a connection
+	// secret.
+	// +optional
+	CACertificate *CACertificateSource `json:"caCertificate,omitempty"`
+
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
 }
 
+// A CACertificateSource references a key of either a Secret or a ConfigMap
+// holding a PEM bundle of CAs.
+type CACertificateSource struct {
+	// SecretRef references a key of a Secret holding the PEM bundle.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// ConfigMapRef references a key of a ConfigMap holding the PEM bundle.
+	// +optional
+	ConfigMapRef *ConfigMapKeySelector `json:"configMapRef,omitempty"`
+}
+
+// A ConfigMapKeySelector selects a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key whose value is selected.
+	Key string `json:"key"`
+}
+
+const (
+	// CredentialsSourceOIDC obtains the argocd auth token with an OIDC client
+	// credentials grant.
+	CredentialsSourceOIDC xpv1.CredentialsSource = "OIDC"
+
+	// CredentialsSourceConnectionSecret reads the whole argocd connection
+	// from the secret referenced by connectionSecretRef.
+	CredentialsSourceConnectionSecret xpv1.CredentialsSource = "ConnectionSecret"
+)
+
 // ProviderCredentials required to authenticate.
 type ProviderCredentials struct {
 	// Source of the provider credentials.
-	// +kubebuilder:validation:Enum=None;Secret;Environment;Filesystem
+	// +kubebuilder:validation:Enum=None;Secret;Environment;Filesystem;OIDC;ConnectionSecret
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// OIDC configures the client credentials grant whose access token is used
+	// as the argocd auth token. Required if the source is OIDC.
+	// +optional
+	OIDC *OIDCCredentials `json:"oidc,omitempty"`
+
+	// ConnectionSecretRef references a secret holding the whole argocd
+	// connection in the keys server, token, ca.crt and insecure. The keys that
+	// are set take precedence over the ProviderConfig. Required if the source
+	// is ConnectionSecret.
+	// +optional
+	ConnectionSecretRef *xpv1.SecretReference `json:"connectionSecretRef,omitempty"`
+}
+
+// OIDCCredentials configure an OIDC client credentials grant for an
+// automation account.
+type OIDCCredentials struct {
+	// Issuer is the URL of the OIDC provider. Its token endpoint is discovered
+	// from the issuer's /.well-known/openid-configuration.
+	Issuer string `json:"issuer"`
+
+	// ClientID of the automation account.
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef references the client secret of the automation account.
+	ClientSecretRef xpv1.SecretKeySelector `json:"clientSecretRef"`
+
+	// Scopes requested for the access token.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // A ProviderConfigStatus represents the status of a ProviderConfig.
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-argocd/apis"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	"github.com/crossplane-contrib/provider-argocd/pkg/preflight"
+)
+
+func main() {
+	var (
+		app            = kingpin.New(filepath.Base(os.Args[0]), "Validate an argocd ProviderConfig by connecting to the argocd API it points to.").DefaultEnvars()
+		providerConfig = app.Arg("provider-config", "Name of the ProviderConfig to validate.").Default("default").String()
+		timeout        = app.Flag("timeout", "Timeout for the whole check such as 10s or 1m.").Default("30s").Duration()
+		caBundle       = app.Flag("ca-bundle", "Path to a PEM encoded CA bundle trusted in addition to the CA of the ProviderConfig or, if it has none, to the system CAs.").Default("").String()
+	)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+	bundle, err := clients.LoadCABundle(*caBundle)
+	kingpin.FatalIfError(err, "Cannot load CA bundle")
+
+	cfg, err := ctrl.GetConfig()
+	kingpin.FatalIfError(err, "Cannot get API server rest config")
+
+	s := runtime.NewScheme()
+	kingpin.FatalIfError(clientgoscheme.AddToScheme(s), "Cannot add core APIs to scheme")
+	kingpin.FatalIfError(apis.AddToScheme(s), "Cannot add argocd APIs to scheme")
+
+	kube, err := client.New(cfg, client.Options{Scheme: s})
+	kingpin.FatalIfError(err, "Cannot create API server client")
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	r := preflight.NewChecker(kube, bundle).Check(ctx, *providerConfig)
+	cancel()
+
+	fmt.Println(r)
+	if !r.Passed() {
+		os.Exit(1)
+	}
+}
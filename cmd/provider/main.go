@@ -17,8 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	// Embed the time zone database, so that sync window time zones can be
+	// validated in images without one.
+	_ "time/tzdata"
 
 	"gopkg.in/alecthomas/kingpin.v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -27,17 +31,48 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
 	"github.com/crossplane-contrib/provider-argocd/apis"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/options"
+	"github.com/crossplane-contrib/provider-argocd/pkg/jitter"
+	"github.com/crossplane-contrib/provider-argocd/pkg/shard"
+	"github.com/crossplane-contrib/provider-argocd/pkg/tracing"
 )
 
 func main() {
 	var (
-		app            = kingpin.New(filepath.Base(os.Args[0]), "Cluster API support for Crossplane.").DefaultEnvars()
-		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
-		syncPeriod     = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
-		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		app             = kingpin.New(filepath.Base(os.Args[0]), "Cluster API support for Crossplane.").DefaultEnvars()
+		debug           = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
+		syncPeriod      = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
+		leaderElection  = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		argocdNamespace = app.Flag("argocd-namespace", "Namespace ArgoCD is installed in. Used as the default namespace of Applications and the namespace AppProjects are created in.").Default("argocd").String()
+		caBundle        = app.Flag("ca-bundle", "Path to a PEM encoded CA bundle trusted for all ProviderConfigs, in addition to their own CA or, if they have none, to the system CAs.").Default("").String()
+		otlpEndpoint    = app.Flag("otlp-endpoint", "host:port of an OTLP gRPC receiver that traces of reconciles and ArgoCD API calls are exported to. If empty, tracing is disabled.").Default("").String()
+		otlpInsecure    = app.Flag("otlp-insecure", "Connect to the OTLP receiver without TLS.").Default("false").Bool()
+		jitterMax       = app.Flag("reconcile-jitter", "Maximum random delay of the first reconcile of a new managed resource, such as 30s, so that resources created at once do not all call ArgoCD at the same time.").Default("0s").Duration()
+		jitterByKind    = app.Flag("reconcile-jitter-kind", "Maximum random delay of the first reconcile by kind, such as Application=1m. Overrides --reconcile-jitter. Can be repeated.").StringMap()
+		lateInit        = app.Flag("late-initialization", "Copy the observed values of fields that are unset in the spec of managed resources into the spec. Disable with --no-late-initialization.").Default("true").Bool()
+		shardSelector   = app.Flag("shard-selector", "Label selector of the managed resources reconciled by this instance, such as shard=eu, to partition them between several instances. If empty, all resources are reconciled.").Default("").String()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
+	if *argocdNamespace == "" {
+		kingpin.Fatalf("--argocd-namespace must not be empty")
+	}
+	bundle, err := clients.LoadCABundle(*caBundle)
+	kingpin.FatalIfError(err, "Cannot load CA bundle")
+	shardSel, err := shard.Parse(*shardSelector)
+	kingpin.FatalIfError(err, "Cannot parse shard selector")
+
+	maxByKind, err := jitter.ParseMaxByKind(*jitterByKind)
+	kingpin.FatalIfError(err, "Cannot parse reconcile jitter")
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{Endpoint: *otlpEndpoint, Insecure: *otlpInsecure})
+	kingpin.FatalIfError(err, "Cannot setup tracing")
+
+	poolOpts := []clients.PoolOption{clients.WithCABundle(bundle)}
+	if *otlpEndpoint != "" {
+		poolOpts = append(poolOpts, clients.WithUnaryInterceptors(tracing.UnaryClientInterceptor()))
+	}
 
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-argocd"))
@@ -55,11 +90,20 @@ func main() {
 
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		LeaderElection:   *leaderElection,
-		LeaderElectionID: "crossplane-leader-election-provider-argocd",
+		LeaderElectionID: shard.LeaderElectionID("crossplane-leader-election-provider-argocd", shardSel),
 		SyncPeriod:       syncPeriod,
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add argocd APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log), "Cannot setup argocd controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+	kingpin.FatalIfError(controller.Setup(mgr, log, options.Options{
+		Pool:           clients.NewPool(poolOpts...),
+		Jitter:         jitter.Options{Max: *jitterMax, MaxByKind: maxByKind},
+		LateInitialize: *lateInit,
+		ShardSelector:  shardSel,
+		Namespace:      *argocdNamespace,
+	}), "Cannot setup argocd controllers")
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	// Flush the spans of the last reconciles.
+	_ = shutdownTracing(context.Background())
+	kingpin.FatalIfError(err, "Cannot start controller manager")
 }
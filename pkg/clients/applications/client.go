@@ -2,6 +2,7 @@ package applications
 
 import (
 	"context"
+	"io"
 	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
@@ -9,6 +10,8 @@ import (
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 
 	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 )
 
 const (
@@ -31,12 +34,58 @@ type ServiceClient interface {
 
 	// Delete deletes an application
 	Delete(ctx context.Context, in *application.ApplicationDeleteRequest, opts ...grpc.CallOption) (*application.ApplicationResponse, error)
+
+	// TerminateOperation terminates the currently running operation
+	TerminateOperation(ctx context.Context, in *application.OperationTerminateRequest, opts ...grpc.CallOption) (*application.OperationTerminateResponse, error)
+
+	// Sync starts a sync of an application
+	Sync(ctx context.Context, in *application.ApplicationSyncRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error)
+}
+
+// NewApplicationServiceClient returns the application service client of the supplied pooled client.
+func NewApplicationServiceClient(c *clients.PooledClient) (ServiceClient, error) {
+	return clients.Service(c, "application", func(c apiclient.Client, interceptors []grpc.UnaryClientInterceptor) (io.Closer, ServiceClient, error) {
+		closer, repoIf, err := c.NewApplicationClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return closer, &interceptedClient{repoIf, interceptors}, nil
+	})
+}
+
+// interceptedClient runs the interceptors of its Pool around the calls made
+// by the provider.
+type interceptedClient struct {
+	application.ApplicationServiceClient
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+func (c *interceptedClient) Get(ctx context.Context, in *application.ApplicationQuery, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return clients.Invoke(ctx, c.interceptors, "/application.ApplicationService/Get", in, c.ApplicationServiceClient.Get, opts...)
+}
+
+func (c *interceptedClient) List(ctx context.Context, in *application.ApplicationQuery, opts ...grpc.CallOption) (*v1alpha1.ApplicationList, error) {
+	return clients.Invoke(ctx, c.interceptors, "/application.ApplicationService/List", in, c.ApplicationServiceClient.List, opts...)
+}
+
+func (c *interceptedClient) Create(ctx context.Context, in *application.ApplicationCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return clients.Invoke(ctx, c.interceptors, "/application.ApplicationService/Create", in, c.ApplicationServiceClient.Create, opts...)
+}
+
+func (c *interceptedClient) Update(ctx context.Context, in *application.ApplicationUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return clients.Invoke(ctx, c.interceptors, "/application.ApplicationService/Update", in, c.ApplicationServiceClient.Update, opts...)
+}
+
+func (c *interceptedClient) Delete(ctx context.Context, in *application.ApplicationDeleteRequest, opts ...grpc.CallOption) (*application.ApplicationResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/application.ApplicationService/Delete", in, c.ApplicationServiceClient.Delete, opts...)
+}
+
+func (c *interceptedClient) TerminateOperation(ctx context.Context, in *application.OperationTerminateRequest, opts ...grpc.CallOption) (*application.OperationTerminateResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/application.ApplicationService/TerminateOperation", in, c.ApplicationServiceClient.TerminateOperation, opts...)
 }
 
-// NewApplicationServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
-func NewApplicationServiceClient(clientOpts *apiclient.ClientOptions) ServiceClient {
-	_, repoIf := apiclient.NewClientOrDie(clientOpts).NewApplicationClientOrDie()
-	return repoIf
+func (c *interceptedClient) Sync(ctx context.Context, in *application.ApplicationSyncRequest, opts ...grpc.CallOption) (*v1alpha1.Application, error) {
+	return clients.Invoke(ctx, c.interceptors, "/application.ApplicationService/Sync", in, c.ApplicationServiceClient.Sync, opts...)
 }
 
 // IsErrorApplicationNotFound helper function to test for errorNotFound error.
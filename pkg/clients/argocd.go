@@ -18,12 +18,16 @@ package clients
 
 import (
 	"context"
-	"os"
+	"net"
+	"strings"
+	"time"
 
 	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
+	"google.golang.org/grpc/status"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,21 +50,63 @@ func NewClient(opts *argocd.ClientOptions) *argocd.Client {
 	return &cl
 }
 
+// AnnotationKeyProviderConfigSelector is the annotation of a managed resource
+// holding a label selector that selects its ProviderConfig. If set, it takes
+// precedence over the providerConfigRef.
+const AnnotationKeyProviderConfigSelector = "argocd.crossplane.io/provider-config-selector"
+
 // GetConfig constructs a Config that can be used to authenticate to argocd
-// API by the argocd Go client
-func GetConfig(ctx context.Context, c client.Client, mg resource.Managed) (*argocd.ClientOptions, error) {
+// API by the argocd Go client. The clients trust the supplied CA bundle, which
+// may be nil.
+func GetConfig(ctx context.Context, c client.Client, mg resource.Managed, b *CABundle) (*argocd.ClientOptions, error) {
+	if err := selectProviderConfig(ctx, c, mg); err != nil {
+		return nil, err
+	}
 	switch {
 	case mg.GetProviderConfigReference() != nil:
-		return UseProviderConfig(ctx, c, mg)
+		return UseProviderConfig(ctx, c, mg, b)
 	default:
 		return nil, errors.New("providerConfigRef is not given")
 	}
 }
 
+// selectProviderConfig points the providerConfigRef of the supplied managed
+// resource to the single ProviderConfig matching its selector annotation, if
+// any.
+func selectProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) error {
+	sel, ok := mg.GetAnnotations()[AnnotationKeyProviderConfigSelector]
+	if !ok {
+		return nil
+	}
+	s, err := labels.Parse(sel)
+	if err != nil {
+		return errors.Wrap(err, "cannot parse ProviderConfig selector")
+	}
+	l := &v1alpha1.ProviderConfigList{}
+	if err := c.List(ctx, l, client.MatchingLabelsSelector{Selector: s}); err != nil {
+		return errors.Wrap(err, "cannot list ProviderConfigs")
+	}
+	switch len(l.Items) {
+	case 0:
+		return errors.Errorf("no ProviderConfig matches selector %q", sel)
+	case 1:
+		mg.SetProviderConfigReference(&xpv1.Reference{Name: l.Items[0].Name})
+		return nil
+	default:
+		return errors.Errorf("%d ProviderConfigs match selector %q, expected exactly one", len(l.Items), sel)
+	}
+}
+
 // UseProviderConfig to produce a config that can be used to authenticate to AWS.
-func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (*argocd.ClientOptions, error) {
+func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed, b *CABundle) (*argocd.ClientOptions, error) {
+	name := mg.GetProviderConfigReference().Name
 	pc := &v1alpha1.ProviderConfig{}
-	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		// ProviderConfigs in use can't be deleted, but a resource may
+		// reference one that was never created or deleted before it was used.
+		if kerrors.IsNotFound(err) {
+			mg.SetConditions(v1alpha1.ProviderConfigMissing(name))
+		}
 		return nil, errors.Wrap(err, "cannot get referenced Provider")
 	}
 
@@ -69,51 +115,136 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 		return nil, errors.Wrap(err, "cannot track ProviderConfig usage")
 	}
 
-	insecure := ptr.Deref(pc.Spec.Insecure, false)
-	plaintext := ptr.Deref(pc.Spec.PlainText, false)
-
-	authToken, err := authFromCredentials(ctx, c, pc.Spec.Credentials)
+	opts, err := ClientOptionsFor(ctx, c, pc, b)
 	if err != nil {
 		return nil, err
 	}
+	if pc.Spec.DialTimeout != nil {
+		if err := checkDial(ctx, (&net.Dialer{}).DialContext, opts, pc.Spec.DialTimeout.Duration); err != nil {
+			return nil, err
+		}
+	}
+	return opts, nil
+}
+
+// A dialFn connects to the given address.
+type dialFn func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// checkDial fails if no TCP connection to the argocd server can be established
+// within the given timeout. The argocd client dials without a deadline, which
+// would otherwise block the reconcile for an unreachable server.
+func checkDial(ctx context.Context, dial dialFn, opts *argocd.ClientOptions, timeout time.Duration) error {
+	addr := opts.ServerAddr
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		port := "443"
+		if opts.PlainText {
+			port = "80"
+		}
+		addr = net.JoinHostPort(addr, port)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	conn, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "cannot connect to argocd server %s within %s", addr, timeout)
+	}
+	return conn.Close()
+}
+
+// ClientOptionsFor resolves the credentials of the given ProviderConfig and
+// returns the options to connect to the argocd API it points to. The options
+// trust the supplied CA bundle, which may be nil.
+func ClientOptionsFor(ctx context.Context, c client.Client, pc *v1alpha1.ProviderConfig, b *CABundle) (*argocd.ClientOptions, error) {
+	insecure := ptr.Deref(pc.Spec.Insecure, false)
+	plaintext := ptr.Deref(pc.Spec.PlainText, false)
 	grpcWeb := ptr.Deref(pc.Spec.GRPCWeb, false)
 	grpcWebRoot := ptr.Deref(pc.Spec.GRPCWebRootPath, "")
 
-	return &argocd.ClientOptions{
+	opts := &argocd.ClientOptions{
 		ServerAddr:      pc.Spec.ServerAddr,
 		Insecure:        insecure,
 		PlainText:       plaintext,
-		AuthToken:       authToken,
 		GRPCWeb:         grpcWeb,
 		GRPCWebRootPath: grpcWebRoot,
-	}, nil
-}
+	}
 
-func authFromCredentials(ctx context.Context, c client.Client, creds v1alpha1.ProviderCredentials) (string, error) {
-	switch s := creds.Source; s { //nolint:exhaustive
-	case xpv1.CredentialsSourceSecret:
-		csr := creds.SecretRef
-		if csr == nil {
-			return "", errors.New("no credentials secret referenced")
-		}
-		s := &corev1.Secret{}
-		if err := c.Get(ctx, types.NamespacedName{Namespace: csr.Namespace, Name: csr.Name}, s); err != nil {
-			return "", errors.Wrap(err, "cannot get credentials secret")
-		}
-		return string(s.Data[csr.Key]), nil
-	case xpv1.CredentialsSourceFilesystem:
-		fs := creds.Fs
-		if fs == nil {
-			return "", errors.New("no credentials fs given")
+	var ca []byte
+	if pc.Spec.Credentials.Source == v1alpha1.CredentialsSourceConnectionSecret {
+		var err error
+		if ca, err = applyConnectionSecret(ctx, c, pc.Spec.Credentials.ConnectionSecretRef, opts); err != nil {
+			return nil, err
 		}
-		token, err := os.ReadFile(fs.Path)
+	} else {
+		authToken, err := authFromCredentials(ctx, c, pc.Spec.Credentials)
 		if err != nil {
-			return "", errors.Wrap(err, "cannot read credentials file")
+			return nil, err
 		}
-		return string(token), nil
-	default:
-		return "", errors.Errorf("credentials source %s is not currently supported", s)
+		opts.AuthToken = authToken
+	}
+
+	own, err := resolveCACertificate(ctx, c, pc.Spec.CACertificate)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyCA(opts, b, appendPEM(ca, own)); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func authFromCredentials(ctx context.Context, c client.Client, creds v1alpha1.ProviderCredentials) (string, error) {
+	src, err := credentialSourceFor(c, creds)
+	if err != nil {
+		return "", err
+	}
+	token, err := src.Resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+	// Without a token every request is rejected as unauthenticated, which
+	// hides that e.g. the referenced secret key is missing.
+	if len(token) == 0 {
+		return "", errors.Errorf(errFmtEmptyAuthToken, creds.Source)
+	}
+	return string(token), nil
+}
+
+// The argocd server, or the ingress in front of it, answers with 503 Service
+// Unavailable while ArgoCD is in maintenance or upgraded. The gRPC client
+// reports it as one of these messages, depending on whether gRPC-Web is used.
+var maintenanceMessages = []string{
+	"unexpected HTTP status code received from server: 503",
+	"failed with status code 503",
+}
+
+// IsErrorMaintenance returns true if the argocd server answered with 503
+// Service Unavailable, which is the case while ArgoCD is in maintenance or
+// upgraded. Other errors of code Unavailable, e.g. because the server cannot
+// be resolved or refuses the connection, are no maintenance.
+func IsErrorMaintenance(err error) bool {
+	if err == nil {
+		return false
+	}
+	s, ok := status.FromError(errors.Cause(err))
+	if !ok {
+		return false
+	}
+	for _, m := range maintenanceMessages {
+		if strings.Contains(s.Message(), m) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleMaintenance sets the Maintenance condition on the supplied managed
+// resource if err indicates that ArgoCD is in maintenance. The error is
+// returned as is, so that the reconciliation is retried with backoff.
+func HandleMaintenance(mg resource.Managed, err error) error {
+	if IsErrorMaintenance(err) {
+		mg.SetConditions(v1alpha1.Maintenance())
 	}
+	return err
 }
 
 // LateInitializeStringPtr returns `from` if `in` is nil and `from` is non-empty,
@@ -0,0 +1,302 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+func withProviderConfigs(names ...string) test.MockListFn {
+	return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		l := obj.(*v1alpha1.ProviderConfigList)
+		for _, n := range names {
+			l.Items = append(l.Items, v1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: n}})
+		}
+		return nil
+	}
+}
+
+func TestSelectProviderConfig(t *testing.T) {
+	type want struct {
+		ref *xpv1.Reference
+		err error
+	}
+
+	selector := "region=eu"
+	defaultRef := &xpv1.Reference{Name: "default"}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		list        test.MockListFn
+		want        want
+	}{
+		"NoSelector": {
+			want: want{ref: defaultRef},
+		},
+		"UniqueMatch": {
+			annotations: map[string]string{AnnotationKeyProviderConfigSelector: selector},
+			list:        withProviderConfigs("argocd-eu"),
+			want:        want{ref: &xpv1.Reference{Name: "argocd-eu"}},
+		},
+		"NoMatch": {
+			annotations: map[string]string{AnnotationKeyProviderConfigSelector: selector},
+			list:        withProviderConfigs(),
+			want: want{
+				ref: defaultRef,
+				err: errors.Errorf("no ProviderConfig matches selector %q", selector),
+			},
+		},
+		"AmbiguousMatch": {
+			annotations: map[string]string{AnnotationKeyProviderConfigSelector: selector},
+			list:        withProviderConfigs("argocd-eu-1", "argocd-eu-2"),
+			want: want{
+				ref: defaultRef,
+				err: errors.Errorf("2 ProviderConfigs match selector %q, expected exactly one", selector),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: defaultRef}}
+			mg.SetAnnotations(tc.annotations)
+
+			err := selectProviderConfig(context.Background(), &test.MockClient{MockList: tc.list}, mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ref, mg.GetProviderConfigReference()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUseProviderConfig(t *testing.T) {
+	type want struct {
+		conditions []xpv1.Condition
+		err        error
+	}
+
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{Group: v1alpha1.Group, Resource: "providerconfigs"}, "default")
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		get  test.MockGetFn
+		want want
+	}{
+		"ProviderConfigMissing": {
+			get: test.NewMockGetFn(errNotFound),
+			want: want{
+				conditions: []xpv1.Condition{v1alpha1.ProviderConfigMissing("default")},
+				err:        errors.Wrap(errNotFound, "cannot get referenced Provider"),
+			},
+		},
+		"GetFailed": {
+			get: test.NewMockGetFn(errBoom),
+			want: want{
+				err: errors.Wrap(errBoom, "cannot get referenced Provider"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}}}
+
+			_, err := UseProviderConfig(context.Background(), &test.MockClient{MockGet: tc.get}, mg, nil)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, mg.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckDial(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close() //nolint:errcheck
+
+	timeout := 200 * time.Millisecond
+
+	// blackhole never completes a connection, like a firewalled address.
+	blackhole := func(ctx context.Context, _, _ string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	cases := map[string]struct {
+		dial    dialFn
+		addr    string
+		wantErr bool
+	}{
+		"Reachable": {
+			dial: (&net.Dialer{}).DialContext,
+			addr: l.Addr().String(),
+		},
+		"Blackhole": {
+			dial:    blackhole,
+			addr:    "argocd.example.com",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			err := checkDial(context.Background(), tc.dial, &argocd.ClientOptions{ServerAddr: tc.addr}, timeout)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("checkDial(...): want error %t, got %v", tc.wantErr, err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*timeout {
+				t.Errorf("checkDial(...): took %s, want at most about %s", elapsed, timeout)
+			}
+		})
+	}
+}
+
+func TestClientOptionsFor(t *testing.T) {
+	t.Setenv("ARGOCD_TEST_TOKEN", string(testToken))
+	creds := v1alpha1.ProviderCredentials{
+		Source: xpv1.CredentialsSourceEnvironment,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+			Env: &xpv1.EnvSelector{Name: "ARGOCD_TEST_TOKEN"},
+		},
+	}
+
+	cases := map[string]struct {
+		spec v1alpha1.ProviderConfigSpec
+		want *argocd.ClientOptions
+	}{
+		"Defaults": {
+			spec: v1alpha1.ProviderConfigSpec{
+				ServerAddr:  "argocd.example.com:443",
+				Credentials: creds,
+			},
+			want: &argocd.ClientOptions{
+				ServerAddr: "argocd.example.com:443",
+				AuthToken:  string(testToken),
+			},
+		},
+		"Insecure": {
+			spec: v1alpha1.ProviderConfigSpec{
+				ServerAddr:  "argocd.example.com:443",
+				Insecure:    ptr.To(true),
+				Credentials: creds,
+			},
+			want: &argocd.ClientOptions{
+				ServerAddr: "argocd.example.com:443",
+				AuthToken:  string(testToken),
+				Insecure:   true,
+			},
+		},
+		"PlainText": {
+			spec: v1alpha1.ProviderConfigSpec{
+				ServerAddr:  "argocd-server.argocd.svc:80",
+				PlainText:   ptr.To(true),
+				Insecure:    ptr.To(false),
+				Credentials: creds,
+			},
+			want: &argocd.ClientOptions{
+				ServerAddr: "argocd-server.argocd.svc:80",
+				AuthToken:  string(testToken),
+				PlainText:  true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			opts, err := ClientOptionsFor(context.Background(), &test.MockClient{}, &v1alpha1.ProviderConfig{Spec: tc.spec}, nil)
+			if err != nil {
+				t.Fatalf("ClientOptionsFor(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, opts); diff != "" {
+				t.Errorf("ClientOptionsFor(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsErrorMaintenance(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"ServiceUnavailable": {
+			err:  status.Error(codes.Unavailable, "unexpected HTTP status code received from server: 503 (Service Unavailable); transport: received unexpected content-type \"text/html\""),
+			want: true,
+		},
+		"ServiceUnavailableGRPCWeb": {
+			err:  status.Error(codes.Unknown, "POST https://argocd.example.com/project.ProjectService/Get failed with status code 503"),
+			want: true,
+		},
+		"Wrapped": {
+			err:  errors.Wrap(status.Error(codes.Unavailable, "unexpected HTTP status code received from server: 503 (Service Unavailable)"), "cannot get project"),
+			want: true,
+		},
+		"ConnectionRefused": {
+			err: status.Error(codes.Unavailable, "connection error: desc = \"transport: Error while dialing: dial tcp 10.0.0.1:443: connect: connection refused\""),
+		},
+		"UnknownHost": {
+			err: status.Error(codes.Unavailable, "connection error: desc = \"transport: Error while dialing: dial tcp: lookup argocd.example.com: no such host\""),
+		},
+		"TLSHandshake": {
+			err: status.Error(codes.Unavailable, "connection error: desc = \"transport: authentication handshake failed: x509: certificate signed by unknown authority\""),
+		},
+		"BadGateway": {
+			err: status.Error(codes.Unavailable, "unexpected HTTP status code received from server: 502 (Bad Gateway)"),
+		},
+		"NoStatus": {
+			err: errors.New("unexpected HTTP status code received from server: 503"),
+		},
+		"NoError": {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsErrorMaintenance(tc.err); got != tc.want {
+				t.Errorf("IsErrorMaintenance(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"os"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+const (
+	errReadCABundle          = "cannot read CA bundle"
+	errNoCACertificateRef    = "CA certificate must reference either a secret or a config map"
+	errFmtEmptyCACertificate = "CA certificate key %q of %s %s/%s is empty"
+)
+
+// systemRootFiles are the locations of the system CA bundle of common Linux
+// distributions, as looked up by crypto/x509.
+var systemRootFiles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/ssl/ca-bundle.pem",
+	"/etc/pki/tls/cacert.pem",
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+	"/etc/ssl/cert.pem",
+}
+
+// A CABundle holds PEM encoded CAs that are trusted by the clients of all
+// ProviderConfigs, in addition to their own CA or, if they have none, to the
+// CAs of the system. A nil CABundle trusts no additional CAs.
+type CABundle struct {
+	bundle      []byte
+	systemRoots []byte
+}
+
+// LoadCABundle reads the PEM encoded CAs at the supplied path. An empty path
+// returns a nil CABundle.
+func LoadCABundle(path string) (*CABundle, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path) //nolint:gosec // The path is configured by the operator.
+	if err != nil {
+		return nil, errors.Wrap(err, errReadCABundle)
+	}
+	return &CABundle{bundle: b, systemRoots: readSystemRoots()}, nil
+}
+
+// WithCABundle makes the clients of a Pool trust the supplied CAs, see
+// CABundle.
+func WithCABundle(b *CABundle) PoolOption {
+	return func(p *Pool) {
+		p.caBundle = b
+	}
+}
+
+// readSystemRoots returns the PEM encoded CAs of the system, or nil if there
+// are none. The file named by SSL_CERT_FILE takes precedence.
+func readSystemRoots() []byte {
+	files := systemRootFiles
+	if f := os.Getenv("SSL_CERT_FILE"); f != "" {
+		files = []string{f}
+	}
+	for _, f := range files {
+		if b, err := os.ReadFile(f); err == nil { //nolint:gosec // The system CA bundle is read from well-known locations.
+			return b
+		}
+	}
+	return nil
+}
+
+// applyCA points the supplied options to a file holding the supplied CA
+// bundle and the supplied CA of a single ProviderConfig. The argocd client
+// trusts only the CAs of that file, so the CAs of the system are added as
+// well if the ProviderConfig has no CA of its own. The options are left as
+// they are if there is neither.
+func applyCA(opts *argocd.ClientOptions, b *CABundle, ca []byte) error {
+	var bundle, systemRoots []byte
+	if b != nil {
+		bundle, systemRoots = b.bundle, b.systemRoots
+	}
+	if len(bundle) == 0 && len(ca) == 0 {
+		return nil
+	}
+	pem := append([]byte(nil), bundle...)
+	if len(ca) == 0 {
+		pem = appendPEM(pem, systemRoots)
+	}
+	f, err := writeCAFile(appendPEM(pem, ca))
+	if err != nil {
+		return err
+	}
+	opts.CertFile = f
+	return nil
+}
+
+// appendPEM appends the PEM encoded src to dst, separated by a newline.
+func appendPEM(dst, src []byte) []byte {
+	if len(dst) > 0 && len(src) > 0 && dst[len(dst)-1] != '\n' {
+		dst = append(dst, '\n')
+	}
+	return append(dst, src...)
+}
+
+// resolveCACertificate returns the PEM bundle referenced by the supplied
+// source, or nil if there is none. An empty bundle is an error, since it
+// would silently fall back to the system CAs.
+func resolveCACertificate(ctx context.Context, c client.Client, src *v1alpha1.CACertificateSource) ([]byte, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var (
+		cs                  CredentialSource
+		kind, ns, name, key string
+	)
+	switch {
+	case src.SecretRef != nil && src.ConfigMapRef == nil:
+		cs = &SecretCredentialSource{Client: c, Ref: *src.SecretRef}
+		kind, ns, name, key = "secret", src.SecretRef.Namespace, src.SecretRef.Name, src.SecretRef.Key
+	case src.ConfigMapRef != nil && src.SecretRef == nil:
+		cs = &ConfigMapCredentialSource{Client: c, Namespace: src.ConfigMapRef.Namespace, Name: src.ConfigMapRef.Name, Key: src.ConfigMapRef.Key}
+		kind, ns, name, key = "config map", src.ConfigMapRef.Namespace, src.ConfigMapRef.Name, src.ConfigMapRef.Key
+	default:
+		return nil, errors.New(errNoCACertificateRef)
+	}
+
+	ca, err := cs.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(ca) == 0 {
+		return nil, errors.Errorf(errFmtEmptyCACertificate, key, kind, ns, name)
+	}
+	return ca, nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+// newCA returns a self-signed CA certificate and its PEM encoding.
+func newCA(t *testing.T, name string) (*x509.Certificate, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestClientOptionsForCABundle(t *testing.T) {
+	global, globalPEM := newCA(t, "global")
+	own, ownPEM := newCA(t, "own")
+	system, systemPEM := newCA(t, "system")
+
+	path := filepath.Join(t.TempDir(), "ca-bundle.crt")
+	if err := os.WriteFile(path, globalPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	systemPath := filepath.Join(t.TempDir(), "system.crt")
+	if err := os.WriteFile(systemPath, systemPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SSL_CERT_FILE", systemPath)
+	bundle, err := LoadCABundle(path)
+	if err != nil {
+		t.Fatalf("LoadCABundle(...): %v", err)
+	}
+
+	cases := map[string]struct {
+		creds     v1alpha1.ProviderCredentials
+		ca        *v1alpha1.CACertificateSource
+		data      map[string][]byte
+		trusted   []*x509.Certificate
+		untrusted []*x509.Certificate
+	}{
+		"GlobalAndOwnCA": {
+			creds: v1alpha1.ProviderCredentials{
+				Source:              v1alpha1.CredentialsSourceConnectionSecret,
+				ConnectionSecretRef: &xpv1.SecretReference{Namespace: "crossplane-system", Name: "argocd-connection"},
+			},
+			data:      map[string][]byte{ConnectionSecretKeyToken: testToken, ConnectionSecretKeyCA: ownPEM},
+			trusted:   []*x509.Certificate{global, own},
+			untrusted: []*x509.Certificate{system},
+		},
+		"GlobalCAOnly": {
+			creds: v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{Key: "authToken"},
+				},
+			},
+			data:    map[string][]byte{"authToken": testToken},
+			trusted: []*x509.Certificate{global, system},
+		},
+		"GlobalAndProviderConfigCA": {
+			creds: v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{Key: "authToken"},
+				},
+			},
+			ca: &v1alpha1.CACertificateSource{
+				SecretRef: &xpv1.SecretKeySelector{Key: "ca.crt"},
+			},
+			data:      map[string][]byte{"authToken": testToken, "ca.crt": ownPEM},
+			trusted:   []*x509.Certificate{global, own},
+			untrusted: []*x509.Certificate{system},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{
+				ServerAddr:    "argocd.example.com:443",
+				CACertificate: tc.ca,
+				Credentials:   tc.creds,
+			}}
+			opts, err := ClientOptionsFor(context.Background(), withConnectionSecret(tc.data), pc, bundle)
+			if err != nil {
+				t.Fatalf("ClientOptionsFor(...): %v", err)
+			}
+			b, err := os.ReadFile(opts.CertFile)
+			if err != nil {
+				t.Fatalf("ClientOptionsFor(...): cannot read CA file: %v", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(b) {
+				t.Fatalf("ClientOptionsFor(...): CA file holds no certificates")
+			}
+			for _, c := range tc.trusted {
+				if _, err := c.Verify(x509.VerifyOptions{Roots: pool}); err != nil {
+					t.Errorf("ClientOptionsFor(...): CA %q is not trusted: %v", c.Subject.CommonName, err)
+				}
+			}
+			for _, c := range tc.untrusted {
+				if _, err := c.Verify(x509.VerifyOptions{Roots: pool}); err == nil {
+					t.Errorf("ClientOptionsFor(...): CA %q is trusted", c.Subject.CommonName)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveCACertificate(t *testing.T) {
+	type want struct {
+		ca  []byte
+		err error
+	}
+
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"ca.crt": testCA, "empty": {}}
+			case *corev1.ConfigMap:
+				o.Data = map[string]string{"ca.crt": string(testCA)}
+			}
+			return nil
+		},
+	}
+	secretRef := func(key string) *xpv1.SecretKeySelector {
+		return &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "argocd-ca"},
+			Key:             key,
+		}
+	}
+	configMapRef := func(key string) *v1alpha1.ConfigMapKeySelector {
+		return &v1alpha1.ConfigMapKeySelector{Namespace: "crossplane-system", Name: "argocd-ca", Key: key}
+	}
+
+	cases := map[string]struct {
+		src  *v1alpha1.CACertificateSource
+		want want
+	}{
+		"None": {},
+		"Secret": {
+			src:  &v1alpha1.CACertificateSource{SecretRef: secretRef("ca.crt")},
+			want: want{ca: testCA},
+		},
+		"ConfigMap": {
+			src:  &v1alpha1.CACertificateSource{ConfigMapRef: configMapRef("ca.crt")},
+			want: want{ca: testCA},
+		},
+		"SecretKeyEmpty": {
+			src:  &v1alpha1.CACertificateSource{SecretRef: secretRef("empty")},
+			want: want{err: errors.Errorf(errFmtEmptyCACertificate, "empty", "secret", "crossplane-system", "argocd-ca")},
+		},
+		"ConfigMapKeyMissing": {
+			src:  &v1alpha1.CACertificateSource{ConfigMapRef: configMapRef("tls.crt")},
+			want: want{err: errors.Errorf(errFmtEmptyCACertificate, "tls.crt", "config map", "crossplane-system", "argocd-ca")},
+		},
+		"NoRef": {
+			src:  &v1alpha1.CACertificateSource{},
+			want: want{err: errors.New(errNoCACertificateRef)},
+		},
+		"BothRefs": {
+			src:  &v1alpha1.CACertificateSource{SecretRef: secretRef("ca.crt"), ConfigMapRef: configMapRef("ca.crt")},
+			want: want{err: errors.New(errNoCACertificateRef)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ca, err := resolveCACertificate(context.Background(), kube, tc.src)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("resolveCACertificate(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ca, ca); diff != "" {
+				t.Errorf("resolveCACertificate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package certificates
+
+import (
+	"context"
+	"io"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/certificate"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+// ServiceClient wraps the functions to connect to argocd repository certificates
+type ServiceClient interface {
+	// ListCertificates lists all available repository certificates
+	ListCertificates(ctx context.Context, in *certificate.RepositoryCertificateQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error)
+	// CreateCertificate creates repository certificates on the server
+	CreateCertificate(ctx context.Context, in *certificate.RepositoryCertificateCreateRequest, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error)
+	// DeleteCertificate deletes the certificates that match the RepositoryCertificateQuery
+	DeleteCertificate(ctx context.Context, in *certificate.RepositoryCertificateQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error)
+}
+
+// NewCertificateServiceClient returns the certificate service client of the supplied pooled client.
+func NewCertificateServiceClient(c *clients.PooledClient) (certificate.CertificateServiceClient, error) {
+	return clients.Service(c, "certificate", func(c apiclient.Client, interceptors []grpc.UnaryClientInterceptor) (io.Closer, certificate.CertificateServiceClient, error) {
+		closer, certIf, err := c.NewCertClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return closer, &interceptedClient{certIf, interceptors}, nil
+	})
+}
+
+// interceptedClient runs the interceptors of its Pool around the calls made
+// by the provider.
+type interceptedClient struct {
+	certificate.CertificateServiceClient
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+func (c *interceptedClient) ListCertificates(ctx context.Context, in *certificate.RepositoryCertificateQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error) {
+	return clients.Invoke(ctx, c.interceptors, "/certificate.CertificateService/ListCertificates", in, c.CertificateServiceClient.ListCertificates, opts...)
+}
+
+func (c *interceptedClient) CreateCertificate(ctx context.Context, in *certificate.RepositoryCertificateCreateRequest, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error) {
+	return clients.Invoke(ctx, c.interceptors, "/certificate.CertificateService/CreateCertificate", in, c.CertificateServiceClient.CreateCertificate, opts...)
+}
+
+func (c *interceptedClient) DeleteCertificate(ctx context.Context, in *certificate.RepositoryCertificateQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error) {
+	return clients.Invoke(ctx, c.interceptors, "/certificate.CertificateService/DeleteCertificate", in, c.CertificateServiceClient.DeleteCertificate, opts...)
+}
+
+// IsErrorCertificateNotFound returns true if the certificate to delete does
+// not exist.
+func IsErrorCertificateNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"context"
+	"io"
 	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
@@ -9,6 +10,8 @@ import (
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 
 	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 )
 
 const (
@@ -28,10 +31,38 @@ type ServiceClient interface {
 	Delete(ctx context.Context, in *cluster.ClusterQuery, opts ...grpc.CallOption) (*cluster.ClusterResponse, error)
 }
 
-// NewClusterServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
-func NewClusterServiceClient(clientOpts *apiclient.ClientOptions) cluster.ClusterServiceClient {
-	_, repoIf := apiclient.NewClientOrDie(clientOpts).NewClusterClientOrDie()
-	return repoIf
+// NewClusterServiceClient returns the cluster service client of the supplied pooled client.
+func NewClusterServiceClient(c *clients.PooledClient) (cluster.ClusterServiceClient, error) {
+	return clients.Service(c, "cluster", func(c apiclient.Client, interceptors []grpc.UnaryClientInterceptor) (io.Closer, cluster.ClusterServiceClient, error) {
+		closer, repoIf, err := c.NewClusterClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return closer, &interceptedClient{repoIf, interceptors}, nil
+	})
+}
+
+// interceptedClient runs the interceptors of its Pool around the calls made
+// by the provider.
+type interceptedClient struct {
+	cluster.ClusterServiceClient
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+func (c *interceptedClient) Create(ctx context.Context, in *cluster.ClusterCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Cluster, error) {
+	return clients.Invoke(ctx, c.interceptors, "/cluster.ClusterService/Create", in, c.ClusterServiceClient.Create, opts...)
+}
+
+func (c *interceptedClient) Get(ctx context.Context, in *cluster.ClusterQuery, opts ...grpc.CallOption) (*v1alpha1.Cluster, error) {
+	return clients.Invoke(ctx, c.interceptors, "/cluster.ClusterService/Get", in, c.ClusterServiceClient.Get, opts...)
+}
+
+func (c *interceptedClient) Update(ctx context.Context, in *cluster.ClusterUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.Cluster, error) {
+	return clients.Invoke(ctx, c.interceptors, "/cluster.ClusterService/Update", in, c.ClusterServiceClient.Update, opts...)
+}
+
+func (c *interceptedClient) Delete(ctx context.Context, in *cluster.ClusterQuery, opts ...grpc.CallOption) (*cluster.ClusterResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/cluster.ClusterService/Delete", in, c.ClusterServiceClient.Delete, opts...)
 }
 
 // IsErrorClusterNotFound helper function to test for errorClusterNotFound error.
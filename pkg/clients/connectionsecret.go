@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+// Keys of a connection secret.
+const (
+	ConnectionSecretKeyServer   = "server"
+	ConnectionSecretKeyToken    = "token"
+	ConnectionSecretKeyCA       = "ca.crt"
+	ConnectionSecretKeyInsecure = "insecure"
+)
+
+const (
+	errNoConnectionSecretRef = "no connection secret referenced"
+	errGetConnectionSecret   = "cannot get connection secret"
+	errParseInsecure         = "cannot parse key insecure of connection secret"
+	errWriteCAFile           = "cannot write CA file"
+	errNoServerAddr          = "no argocd server address given, neither by serverAddr nor by key server of the connection secret"
+)
+
+// applyConnectionSecret sets the server, auth token and insecure flag of the
+// supplied options to the values of the referenced connection secret, and
+// returns its CA. Keys that are not set leave the options as they are. All
+// values are read with a single Get, so that they always stem from the same
+// version of the secret. The options must hold a server address and an auth
+// token afterwards.
+func applyConnectionSecret(ctx context.Context, c client.Client, ref *xpv1.SecretReference, opts *argocd.ClientOptions) ([]byte, error) {
+	if ref == nil {
+		return nil, errors.New(errNoConnectionSecretRef)
+	}
+	s := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetConnectionSecret)
+	}
+
+	if v, ok := s.Data[ConnectionSecretKeyServer]; ok {
+		opts.ServerAddr = string(v)
+	}
+	if v, ok := s.Data[ConnectionSecretKeyToken]; ok {
+		opts.AuthToken = string(v)
+	}
+	if v, ok := s.Data[ConnectionSecretKeyInsecure]; ok {
+		insecure, err := strconv.ParseBool(string(v))
+		if err != nil {
+			return nil, errors.Wrap(err, errParseInsecure)
+		}
+		opts.Insecure = insecure
+	}
+	if opts.ServerAddr == "" {
+		return nil, errors.New(errNoServerAddr)
+	}
+	// Without a token every request is rejected as unauthenticated, which
+	// hides that e.g. the key is missing.
+	if opts.AuthToken == "" {
+		return nil, errors.Errorf(errFmtEmptyAuthToken, v1alpha1.CredentialsSourceConnectionSecret)
+	}
+	return s.Data[ConnectionSecretKeyCA], nil
+}
+
+// writeCAFile writes the supplied PEM encoded certificates to a file and
+// returns its path. The argocd client only reads CAs from files. The file is
+// named after its content, so it is written once per distinct CA.
+func writeCAFile(pem []byte) (string, error) {
+	h := sha256.Sum256(pem)
+	path := filepath.Join(os.TempDir(), "provider-argocd-ca-"+hex.EncodeToString(h[:8])+".crt")
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	tmp, err := os.CreateTemp(os.TempDir(), "provider-argocd-ca-*")
+	if err != nil {
+		return "", errors.Wrap(err, errWriteCAFile)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // Removing the renamed file fails, which is fine.
+	if _, err := tmp.Write(pem); err != nil {
+		_ = tmp.Close()
+		return "", errors.Wrap(err, errWriteCAFile)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", errors.Wrap(err, errWriteCAFile)
+	}
+	// Renaming is atomic, so concurrent reconciles never read a partial file.
+	return path, errors.Wrap(os.Rename(tmp.Name(), path), errWriteCAFile)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+var testCA = []byte("-----BEGIN CERTIFICATE-----\nMIIBtest\n-----END CERTIFICATE-----\n")
+
+func withConnectionSecret(data map[string][]byte) *test.MockClient {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = data
+			return nil
+		},
+	}
+}
+
+func TestClientOptionsForConnectionSecret(t *testing.T) {
+	type want struct {
+		opts *argocd.ClientOptions
+		ca   []byte
+		err  error
+	}
+
+	ref := &xpv1.SecretReference{Namespace: "crossplane-system", Name: "argocd-connection"}
+	_, errParse := strconv.ParseBool("maybe")
+
+	cases := map[string]struct {
+		kube client.Client
+		spec v1alpha1.ProviderConfigSpec
+		want want
+	}{
+		"AllKeys": {
+			kube: withConnectionSecret(map[string][]byte{
+				ConnectionSecretKeyServer:   []byte("argocd.example.com:443"),
+				ConnectionSecretKeyToken:    testToken,
+				ConnectionSecretKeyCA:       testCA,
+				ConnectionSecretKeyInsecure: []byte("true"),
+			}),
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{
+					Source:              v1alpha1.CredentialsSourceConnectionSecret,
+					ConnectionSecretRef: ref,
+				},
+			},
+			want: want{
+				opts: &argocd.ClientOptions{
+					ServerAddr: "argocd.example.com:443",
+					AuthToken:  string(testToken),
+					Insecure:   true,
+				},
+				ca: testCA,
+			},
+		},
+		"ServerFromProviderConfig": {
+			kube: withConnectionSecret(map[string][]byte{
+				ConnectionSecretKeyToken: testToken,
+			}),
+			spec: v1alpha1.ProviderConfigSpec{
+				ServerAddr: "argocd-server.argocd.svc:443",
+				Credentials: v1alpha1.ProviderCredentials{
+					Source:              v1alpha1.CredentialsSourceConnectionSecret,
+					ConnectionSecretRef: ref,
+				},
+			},
+			want: want{
+				opts: &argocd.ClientOptions{
+					ServerAddr: "argocd-server.argocd.svc:443",
+					AuthToken:  string(testToken),
+				},
+			},
+		},
+		"InvalidInsecure": {
+			kube: withConnectionSecret(map[string][]byte{
+				ConnectionSecretKeyInsecure: []byte("maybe"),
+			}),
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{
+					Source:              v1alpha1.CredentialsSourceConnectionSecret,
+					ConnectionSecretRef: ref,
+				},
+			},
+			want: want{err: errors.Wrap(errParse, errParseInsecure)},
+		},
+		"NoServer": {
+			kube: withConnectionSecret(map[string][]byte{
+				ConnectionSecretKeyToken: testToken,
+			}),
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{
+					Source:              v1alpha1.CredentialsSourceConnectionSecret,
+					ConnectionSecretRef: ref,
+				},
+			},
+			want: want{err: errors.New(errNoServerAddr)},
+		},
+		"NoToken": {
+			kube: withConnectionSecret(map[string][]byte{
+				ConnectionSecretKeyServer: []byte("argocd.example.com:443"),
+			}),
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{
+					Source:              v1alpha1.CredentialsSourceConnectionSecret,
+					ConnectionSecretRef: ref,
+				},
+			},
+			want: want{err: errors.Errorf(errFmtEmptyAuthToken, v1alpha1.CredentialsSourceConnectionSecret)},
+		},
+		"EmptyToken": {
+			kube: withConnectionSecret(map[string][]byte{
+				ConnectionSecretKeyServer: []byte("argocd.example.com:443"),
+				ConnectionSecretKeyToken:  {},
+			}),
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{
+					Source:              v1alpha1.CredentialsSourceConnectionSecret,
+					ConnectionSecretRef: ref,
+				},
+			},
+			want: want{err: errors.Errorf(errFmtEmptyAuthToken, v1alpha1.CredentialsSourceConnectionSecret)},
+		},
+		"NoConnectionSecretRef": {
+			kube: &test.MockClient{},
+			spec: v1alpha1.ProviderConfigSpec{
+				Credentials: v1alpha1.ProviderCredentials{
+					Source: v1alpha1.CredentialsSourceConnectionSecret,
+				},
+			},
+			want: want{err: errors.New(errNoConnectionSecretRef)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			opts, err := ClientOptionsFor(context.Background(), tc.kube, &v1alpha1.ProviderConfig{Spec: tc.spec}, nil)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("ClientOptionsFor(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.opts, opts, cmpopts.IgnoreFields(argocd.ClientOptions{}, "CertFile")); diff != "" {
+				t.Errorf("ClientOptionsFor(...): -want, +got:\n%s", diff)
+			}
+			if opts == nil || opts.CertFile == "" {
+				if tc.want.ca != nil {
+					t.Errorf("ClientOptionsFor(...): want CA file, got none")
+				}
+				return
+			}
+			ca, err := os.ReadFile(opts.CertFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want.ca, ca); diff != "" {
+				t.Errorf("CA file: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClientOptionsForConnectionSecretSingleRead(t *testing.T) {
+	var gets int
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			gets++
+			obj.(*corev1.Secret).Data = map[string][]byte{
+				ConnectionSecretKeyServer: []byte("argocd.example.com:443"),
+				ConnectionSecretKeyToken:  testToken,
+			}
+			return nil
+		},
+	}
+	pc := &v1alpha1.ProviderConfig{Spec: v1alpha1.ProviderConfigSpec{
+		Credentials: v1alpha1.ProviderCredentials{
+			Source:              v1alpha1.CredentialsSourceConnectionSecret,
+			ConnectionSecretRef: &xpv1.SecretReference{Namespace: "crossplane-system", Name: "argocd-connection"},
+		},
+	}}
+
+	opts, err := ClientOptionsFor(context.Background(), kube, pc, nil)
+	if err != nil {
+		t.Fatalf("ClientOptionsFor(...): %v", err)
+	}
+	if diff := cmp.Diff(1, gets); diff != "" {
+		t.Errorf("ClientOptionsFor(...) secret reads: -want, +got:\n%s", diff)
+	}
+	if opts.ServerAddr != "argocd.example.com:443" || opts.AuthToken != string(testToken) {
+		t.Errorf("ClientOptionsFor(...): want server and token of the secret, got %q and %q", opts.ServerAddr, opts.AuthToken)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+const (
+	errNoSecretRef        = "no credentials secret referenced"
+	errNoEnv              = "no credentials environment variable given"
+	errNoFs               = "no credentials fs given"
+	errGetSecret          = "cannot get credentials secret"
+	errGetConfigMap       = "cannot get credentials configmap"
+	errReadFile           = "cannot read credentials file"
+	errEnvNotSet          = "credentials environment variable %s is not set"
+	errSourceNotSupported = "credentials source %s is not currently supported"
+	errFmtEmptyAuthToken  = "argocd auth token resolved from credentials source %s is empty"
+)
+
+// A CredentialSource resolves credential data from a single location.
+// Implementations return an error if the location they point to does not
+// exist.
+type CredentialSource interface {
+	Resolve(ctx context.Context) ([]byte, error)
+}
+
+// SecretCredentialSource reads credentials from a key of a Kubernetes Secret.
+type SecretCredentialSource struct {
+	Client client.Client
+	Ref    xpv1.SecretKeySelector
+}
+
+// Resolve returns the value stored under the referenced key. A missing key
+// resolves to empty credentials.
+func (s *SecretCredentialSource) Resolve(ctx context.Context) ([]byte, error) {
+	sc := &corev1.Secret{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.Ref.Namespace, Name: s.Ref.Name}, sc); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+	return sc.Data[s.Ref.Key], nil
+}
+
+// ConfigMapCredentialSource reads credentials from a key of a Kubernetes
+// ConfigMap.
+type ConfigMapCredentialSource struct {
+	Client    client.Client
+	Namespace string
+	Name      string
+	Key       string
+}
+
+// Resolve returns the value stored under the referenced key. A missing key
+// resolves to empty credentials.
+func (s *ConfigMapCredentialSource) Resolve(ctx context.Context) ([]byte, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, cm); err != nil {
+		return nil, errors.Wrap(err, errGetConfigMap)
+	}
+	if v, ok := cm.BinaryData[s.Key]; ok {
+		return v, nil
+	}
+	return []byte(cm.Data[s.Key]), nil
+}
+
+// EnvCredentialSource reads credentials from an environment variable.
+type EnvCredentialSource struct {
+	Name string
+}
+
+// Resolve returns the value of the environment variable.
+func (s *EnvCredentialSource) Resolve(_ context.Context) ([]byte, error) {
+	v, ok := os.LookupEnv(s.Name)
+	if !ok {
+		return nil, errors.Errorf(errEnvNotSet, s.Name)
+	}
+	return []byte(v), nil
+}
+
+// FileCredentialSource reads credentials from a file.
+type FileCredentialSource struct {
+	Path string
+}
+
+// Resolve returns the content of the file.
+func (s *FileCredentialSource) Resolve(_ context.Context) ([]byte, error) {
+	b, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, errReadFile)
+	}
+	return b, nil
+}
+
+// credentialSourceFor returns the CredentialSource configured by the given
+// ProviderCredentials.
+func credentialSourceFor(c client.Client, creds v1alpha1.ProviderCredentials) (CredentialSource, error) {
+	switch s := creds.Source; s { //nolint:exhaustive
+	case xpv1.CredentialsSourceSecret:
+		if creds.SecretRef == nil {
+			return nil, errors.New(errNoSecretRef)
+		}
+		return &SecretCredentialSource{Client: c, Ref: *creds.SecretRef}, nil
+	case xpv1.CredentialsSourceEnvironment:
+		if creds.Env == nil {
+			return nil, errors.New(errNoEnv)
+		}
+		return &EnvCredentialSource{Name: creds.Env.Name}, nil
+	case xpv1.CredentialsSourceFilesystem:
+		if creds.Fs == nil {
+			return nil, errors.New(errNoFs)
+		}
+		return &FileCredentialSource{Path: creds.Fs.Path}, nil
+	case v1alpha1.CredentialsSourceOIDC:
+		if creds.OIDC == nil {
+			return nil, errors.New(errNoOIDC)
+		}
+		return &OIDCCredentialSource{
+			Client:          c,
+			Issuer:          creds.OIDC.Issuer,
+			ClientID:        creds.OIDC.ClientID,
+			ClientSecretRef: creds.OIDC.ClientSecretRef,
+			Scopes:          creds.OIDC.Scopes,
+		}, nil
+	default:
+		return nil, errors.Errorf(errSourceNotSupported, s)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+)
+
+var (
+	testToken       = []byte("s3cr3t")
+	errNotFoundTest = kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "creds")
+)
+
+func TestCredentialSources(t *testing.T) {
+	type want struct {
+		data []byte
+		err  error
+	}
+
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, testToken, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missingFile := filepath.Join(t.TempDir(), "missing")
+	_, errMissingFile := os.ReadFile(missingFile)
+
+	t.Setenv("ARGOCD_TEST_TOKEN", string(testToken))
+
+	cases := map[string]struct {
+		src  CredentialSource
+		want want
+	}{
+		"Secret": {
+			src: &SecretCredentialSource{
+				Client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						obj.(*corev1.Secret).Data = map[string][]byte{"token": testToken}
+						return nil
+					},
+				},
+				Ref: xpv1.SecretKeySelector{Key: "token"},
+			},
+			want: want{data: testToken},
+		},
+		"SecretNotFound": {
+			src: &SecretCredentialSource{
+				Client: &test.MockClient{MockGet: test.NewMockGetFn(errNotFoundTest)},
+				Ref:    xpv1.SecretKeySelector{Key: "token"},
+			},
+			want: want{err: errors.Wrap(errNotFoundTest, errGetSecret)},
+		},
+		"ConfigMap": {
+			src: &ConfigMapCredentialSource{
+				Client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						obj.(*corev1.ConfigMap).Data = map[string]string{"token": string(testToken)}
+						return nil
+					},
+				},
+				Key: "token",
+			},
+			want: want{data: testToken},
+		},
+		"ConfigMapNotFound": {
+			src: &ConfigMapCredentialSource{
+				Client: &test.MockClient{MockGet: test.NewMockGetFn(errNotFoundTest)},
+				Key:    "token",
+			},
+			want: want{err: errors.Wrap(errNotFoundTest, errGetConfigMap)},
+		},
+		"Env": {
+			src:  &EnvCredentialSource{Name: "ARGOCD_TEST_TOKEN"},
+			want: want{data: testToken},
+		},
+		"EnvNotFound": {
+			src:  &EnvCredentialSource{Name: "ARGOCD_TEST_TOKEN_MISSING"},
+			want: want{err: errors.Errorf(errEnvNotSet, "ARGOCD_TEST_TOKEN_MISSING")},
+		},
+		"File": {
+			src:  &FileCredentialSource{Path: file},
+			want: want{data: testToken},
+		},
+		"FileNotFound": {
+			src:  &FileCredentialSource{Path: missingFile},
+			want: want{err: errors.Wrap(errMissingFile, errReadFile)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, err := tc.src.Resolve(context.Background())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAuthFromCredentials(t *testing.T) {
+	type want struct {
+		token string
+		err   error
+	}
+
+	t.Setenv("ARGOCD_TEST_TOKEN", string(testToken))
+
+	secretRef := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "crossplane-system"},
+		Key:             "token",
+	}
+	withSecretData := func(data map[string][]byte) client.Client {
+		return &test.MockClient{
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*corev1.Secret).Data = data
+				return nil
+			}),
+		}
+	}
+
+	cases := map[string]struct {
+		kube  client.Client
+		creds v1alpha1.ProviderCredentials
+		want  want
+	}{
+		"Secret": {
+			kube: withSecretData(map[string][]byte{"token": testToken}),
+			creds: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef},
+			},
+			want: want{token: string(testToken)},
+		},
+		"SecretKeyMissing": {
+			kube: withSecretData(map[string][]byte{"password": testToken}),
+			creds: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef},
+			},
+			want: want{err: errors.Errorf(errFmtEmptyAuthToken, xpv1.CredentialsSourceSecret)},
+		},
+		"SecretTokenEmpty": {
+			kube: withSecretData(map[string][]byte{"token": {}}),
+			creds: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: secretRef},
+			},
+			want: want{err: errors.Errorf(errFmtEmptyAuthToken, xpv1.CredentialsSourceSecret)},
+		},
+		"Environment": {
+			creds: v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceEnvironment,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					Env: &xpv1.EnvSelector{Name: "ARGOCD_TEST_TOKEN"},
+				},
+			},
+			want: want{token: string(testToken)},
+		},
+		"NoSecretRef": {
+			creds: v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret},
+			want:  want{err: errors.New(errNoSecretRef)},
+		},
+		"NoOIDC": {
+			creds: v1alpha1.ProviderCredentials{Source: v1alpha1.CredentialsSourceOIDC},
+			want:  want{err: errors.New(errNoOIDC)},
+		},
+		"Unsupported": {
+			creds: v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+			want:  want{err: errors.Errorf(errSourceNotSupported, xpv1.CredentialsSourceNone)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := tc.kube
+			if kube == nil {
+				kube = &test.MockClient{}
+			}
+			token, err := authFromCredentials(context.Background(), kube, tc.creds)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.token, token); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
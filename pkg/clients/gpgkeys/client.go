@@ -0,0 +1,54 @@
+package gpgkeys
+
+import (
+	"context"
+	"io"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/gpgkey"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+
+	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+// ServiceClient wraps the functions to connect to argocd gpg keys
+type ServiceClient interface {
+	// List all available GPG public keys
+	List(ctx context.Context, in *gpgkey.GnuPGPublicKeyQuery, opts ...grpc.CallOption) (*v1alpha1.GnuPGPublicKeyList, error)
+	// Create one or more GPG public keys in the server's configuration
+	Create(ctx context.Context, in *gpgkey.GnuPGPublicKeyCreateRequest, opts ...grpc.CallOption) (*gpgkey.GnuPGPublicKeyCreateResponse, error)
+	// Delete specified GPG public key from the server's configuration
+	Delete(ctx context.Context, in *gpgkey.GnuPGPublicKeyQuery, opts ...grpc.CallOption) (*gpgkey.GnuPGPublicKeyResponse, error)
+}
+
+// NewGPGKeyServiceClient returns the gpgkey service client of the supplied pooled client.
+func NewGPGKeyServiceClient(c *clients.PooledClient) (gpgkey.GPGKeyServiceClient, error) {
+	return clients.Service(c, "gpgkey", func(c apiclient.Client, interceptors []grpc.UnaryClientInterceptor) (io.Closer, gpgkey.GPGKeyServiceClient, error) {
+		closer, gpgKeyIf, err := c.NewGPGKeyClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return closer, &interceptedClient{gpgKeyIf, interceptors}, nil
+	})
+}
+
+// interceptedClient runs the interceptors of its Pool around the calls made
+// by the provider.
+type interceptedClient struct {
+	gpgkey.GPGKeyServiceClient
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+func (c *interceptedClient) List(ctx context.Context, in *gpgkey.GnuPGPublicKeyQuery, opts ...grpc.CallOption) (*v1alpha1.GnuPGPublicKeyList, error) {
+	return clients.Invoke(ctx, c.interceptors, "/gpgkey.GPGKeyService/List", in, c.GPGKeyServiceClient.List, opts...)
+}
+
+func (c *interceptedClient) Create(ctx context.Context, in *gpgkey.GnuPGPublicKeyCreateRequest, opts ...grpc.CallOption) (*gpgkey.GnuPGPublicKeyCreateResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/gpgkey.GPGKeyService/Create", in, c.GPGKeyServiceClient.Create, opts...)
+}
+
+func (c *interceptedClient) Delete(ctx context.Context, in *gpgkey.GnuPGPublicKeyQuery, opts ...grpc.CallOption) (*gpgkey.GnuPGPublicKeyResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/gpgkey.GPGKeyService/Delete", in, c.GPGKeyServiceClient.Delete, opts...)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// WithUnaryInterceptors adds client interceptors that run around every unary
+// call to the argocd API made through the clients of a Pool, e.g. for audit
+// logging, correlation IDs or tracing. Interceptors run in the order they are
+// supplied.
+//
+// The argocd client dials its gRPC connection itself and offers no way to add
+// interceptors to it, so the service clients run them around their calls
+// instead, see Invoke. The interceptors are passed a nil *grpc.ClientConn.
+func WithUnaryInterceptors(i ...grpc.UnaryClientInterceptor) PoolOption {
+	return func(p *Pool) {
+		p.interceptors = append(p.interceptors, i...)
+	}
+}
+
+// Invoke calls the supplied method of an argocd service client, running the
+// supplied interceptors around it. method is the full gRPC method name, e.g.
+// /project.ProjectService/Get. The interceptors are passed a pointer to the
+// response as reply.
+func Invoke[Req, Resp any](ctx context.Context, interceptors []grpc.UnaryClientInterceptor, method string, req Req, call func(context.Context, Req, ...grpc.CallOption) (Resp, error), opts ...grpc.CallOption) (Resp, error) {
+	if len(interceptors) == 0 {
+		return call(ctx, req, opts...)
+	}
+
+	var resp Resp
+	invoker := func(ctx context.Context, _ string, req, _ any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		var err error
+		resp, err = call(ctx, req.(Req), opts...)
+		return err
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return interceptor(ctx, method, req, reply, cc, next, opts...)
+		}
+	}
+	err := invoker(ctx, method, req, &resp, nil, opts...)
+	return resp, err
+}
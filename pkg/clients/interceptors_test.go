@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestInvoke(t *testing.T) {
+	errBoom := errors.New("boom")
+	var calls []string
+	var replies []any
+	interceptors := []grpc.UnaryClientInterceptor{
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			calls = append(calls, "outer "+method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		},
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			calls = append(calls, "inner "+method)
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			replies = append(replies, *reply.(*string))
+			return err
+		},
+	}
+
+	get := func(_ context.Context, in string, _ ...grpc.CallOption) (string, error) {
+		if in == "" {
+			return "", errBoom
+		}
+		return "got " + in, nil
+	}
+
+	got, err := Invoke(context.Background(), interceptors, "/project.ProjectService/Get", "a", get)
+	if err != nil {
+		t.Fatalf("Invoke(...): %v", err)
+	}
+	if got != "got a" {
+		t.Errorf("Invoke(...): want %q, got %q", "got a", got)
+	}
+	_, err = Invoke(context.Background(), interceptors, "/project.ProjectService/Get", "", get)
+	if diff := cmp.Diff(errBoom, err, test.EquateErrors()); diff != "" {
+		t.Errorf("Invoke(...): -want error, +got error:\n%s", diff)
+	}
+
+	wantCalls := []string{
+		"outer /project.ProjectService/Get",
+		"inner /project.ProjectService/Get",
+		"outer /project.ProjectService/Get",
+		"inner /project.ProjectService/Get",
+	}
+	if diff := cmp.Diff(wantCalls, calls); diff != "" {
+		t.Errorf("interceptor calls: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]any{"got a", ""}, replies); diff != "" {
+		t.Errorf("interceptor replies: -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationKeyLateInitialize overrides whether the observed values of a
+// single managed resource are copied into its spec. It is either "true" or
+// "false".
+const AnnotationKeyLateInitialize = "argocd.crossplane.io/late-initialize"
+
+// ShouldLateInitialize returns whether the observed values of fields that
+// are unset in the spec of the supplied managed resource are copied into its
+// spec. enabled is the default of all managed resources, which a resource
+// overrides with AnnotationKeyLateInitialize.
+func ShouldLateInitialize(o metav1.Object, enabled bool) bool {
+	switch o.GetAnnotations()[AnnotationKeyLateInitialize] {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return enabled
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestShouldLateInitialize(t *testing.T) {
+	cases := map[string]struct {
+		enabled    bool
+		annotation string
+		want       bool
+	}{
+		"EnabledByDefault": {
+			enabled: true,
+			want:    true,
+		},
+		"Disabled": {
+			enabled: false,
+			want:    false,
+		},
+		"DisabledByAnnotation": {
+			enabled:    true,
+			annotation: "false",
+			want:       false,
+		},
+		"EnabledByAnnotation": {
+			enabled:    false,
+			annotation: "true",
+			want:       true,
+		},
+		"InvalidAnnotationIgnored": {
+			enabled:    false,
+			annotation: "no",
+			want:       false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := &metav1.ObjectMeta{}
+			if tc.annotation != "" {
+				o.SetAnnotations(map[string]string{AnnotationKeyLateInitialize: tc.annotation})
+			}
+			if got := ShouldLateInitialize(o, tc.enabled); got != tc.want {
+				t.Errorf("ShouldLateInitialize(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
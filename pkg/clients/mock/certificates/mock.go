@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../certificates/client.go
+
+// Package certificates is a generated GoMock package.
+package certificates
+
+import (
+	context "context"
+	reflect "reflect"
+
+	certificate "github.com/argoproj/argo-cd/v2/pkg/apiclient/certificate"
+	v1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockServiceClient is a mock of ServiceClient interface.
+type MockServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceClientMockRecorder
+}
+
+// MockServiceClientMockRecorder is the mock recorder for MockServiceClient.
+type MockServiceClientMockRecorder struct {
+	mock *MockServiceClient
+}
+
+// NewMockServiceClient creates a new mock instance.
+func NewMockServiceClient(ctrl *gomock.Controller) *MockServiceClient {
+	mock := &MockServiceClient{ctrl: ctrl}
+	mock.recorder = &MockServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceClient) EXPECT() *MockServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateCertificate mocks base method.
+func (m *MockServiceClient) CreateCertificate(ctx context.Context, in *certificate.RepositoryCertificateCreateRequest, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateCertificate", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.RepositoryCertificateList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCertificate indicates an expected call of CreateCertificate.
+func (mr *MockServiceClientMockRecorder) CreateCertificate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCertificate", reflect.TypeOf((*MockServiceClient)(nil).CreateCertificate), varargs...)
+}
+
+// DeleteCertificate mocks base method.
+func (m *MockServiceClient) DeleteCertificate(ctx context.Context, in *certificate.RepositoryCertificateQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCertificate", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.RepositoryCertificateList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCertificate indicates an expected call of DeleteCertificate.
+func (mr *MockServiceClientMockRecorder) DeleteCertificate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCertificate", reflect.TypeOf((*MockServiceClient)(nil).DeleteCertificate), varargs...)
+}
+
+// ListCertificates mocks base method.
+func (m *MockServiceClient) ListCertificates(ctx context.Context, in *certificate.RepositoryCertificateQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryCertificateList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCertificates", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.RepositoryCertificateList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCertificates indicates an expected call of ListCertificates.
+func (mr *MockServiceClientMockRecorder) ListCertificates(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificates", reflect.TypeOf((*MockServiceClient)(nil).ListCertificates), varargs...)
+}
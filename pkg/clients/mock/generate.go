@@ -5,3 +5,7 @@ package mock
 //go:generate go run -mod=mod github.com/golang/mock/mockgen -package application -destination=./applications/mock.go -source=../applications/client.go ServiceClient -build_flags=-mod=mod
 //go:generate go run -mod=mod github.com/golang/mock/mockgen -package projects -destination=./projects/mock.go -source=../projects/client.go ServiceClient -build_flags=-mod=mod
 //go:generate go run -mod=mod github.com/golang/mock/mockgen -package cluster -destination=./cluster/mock.go -source=../cluster/client.go ServiceClient -build_flags=-mod=mod
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -package version -destination=./version/mock.go -source=../version/client.go ServiceClient -build_flags=-mod=mod
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -package repocreds -destination=./repocreds/mock.go -source=../repocreds/client.go ServiceClient -build_flags=-mod=mod
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -package gpgkeys -destination=./gpgkeys/mock.go -source=../gpgkeys/client.go ServiceClient -build_flags=-mod=mod
+//go:generate go run -mod=mod github.com/golang/mock/mockgen -package certificates -destination=./certificates/mock.go -source=../certificates/client.go ServiceClient -build_flags=-mod=mod
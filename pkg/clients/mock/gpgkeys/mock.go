@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../gpgkeys/client.go
+
+// Package gpgkeys is a generated GoMock package.
+package gpgkeys
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gpgkey "github.com/argoproj/argo-cd/v2/pkg/apiclient/gpgkey"
+	v1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockServiceClient is a mock of ServiceClient interface.
+type MockServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceClientMockRecorder
+}
+
+// MockServiceClientMockRecorder is the mock recorder for MockServiceClient.
+type MockServiceClientMockRecorder struct {
+	mock *MockServiceClient
+}
+
+// NewMockServiceClient creates a new mock instance.
+func NewMockServiceClient(ctrl *gomock.Controller) *MockServiceClient {
+	mock := &MockServiceClient{ctrl: ctrl}
+	mock.recorder = &MockServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceClient) EXPECT() *MockServiceClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockServiceClient) Create(ctx context.Context, in *gpgkey.GnuPGPublicKeyCreateRequest, opts ...grpc.CallOption) (*gpgkey.GnuPGPublicKeyCreateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
+	ret0, _ := ret[0].(*gpgkey.GnuPGPublicKeyCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceClientMockRecorder) Create(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockServiceClient)(nil).Create), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockServiceClient) Delete(ctx context.Context, in *gpgkey.GnuPGPublicKeyQuery, opts ...grpc.CallOption) (*gpgkey.GnuPGPublicKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(*gpgkey.GnuPGPublicKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceClientMockRecorder) Delete(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceClient)(nil).Delete), varargs...)
+}
+
+// List mocks base method.
+func (m *MockServiceClient) List(ctx context.Context, in *gpgkey.GnuPGPublicKeyQuery, opts ...grpc.CallOption) (*v1alpha1.GnuPGPublicKeyList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.GnuPGPublicKeyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceClientMockRecorder) List(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockServiceClient)(nil).List), varargs...)
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repocreds/client.go
+
+// Package repocreds is a generated GoMock package.
+package repocreds
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repocreds "github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
+	v1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockServiceClient is a mock of ServiceClient interface.
+type MockServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceClientMockRecorder
+}
+
+// MockServiceClientMockRecorder is the mock recorder for MockServiceClient.
+type MockServiceClientMockRecorder struct {
+	mock *MockServiceClient
+}
+
+// NewMockServiceClient creates a new mock instance.
+func NewMockServiceClient(ctrl *gomock.Controller) *MockServiceClient {
+	mock := &MockServiceClient{ctrl: ctrl}
+	mock.recorder = &MockServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceClient) EXPECT() *MockServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateRepositoryCredentials mocks base method.
+func (m *MockServiceClient) CreateRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsCreateRequest, opts ...grpc.CallOption) (*v1alpha1.RepoCreds, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateRepositoryCredentials", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.RepoCreds)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRepositoryCredentials indicates an expected call of CreateRepositoryCredentials.
+func (mr *MockServiceClientMockRecorder) CreateRepositoryCredentials(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepositoryCredentials", reflect.TypeOf((*MockServiceClient)(nil).CreateRepositoryCredentials), varargs...)
+}
+
+// DeleteRepositoryCredentials mocks base method.
+func (m *MockServiceClient) DeleteRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsDeleteRequest, opts ...grpc.CallOption) (*repocreds.RepoCredsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRepositoryCredentials", varargs...)
+	ret0, _ := ret[0].(*repocreds.RepoCredsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRepositoryCredentials indicates an expected call of DeleteRepositoryCredentials.
+func (mr *MockServiceClientMockRecorder) DeleteRepositoryCredentials(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRepositoryCredentials", reflect.TypeOf((*MockServiceClient)(nil).DeleteRepositoryCredentials), varargs...)
+}
+
+// ListRepositoryCredentials mocks base method.
+func (m *MockServiceClient) ListRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsQuery, opts ...grpc.CallOption) (*v1alpha1.RepoCredsList, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRepositoryCredentials", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.RepoCredsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRepositoryCredentials indicates an expected call of ListRepositoryCredentials.
+func (mr *MockServiceClientMockRecorder) ListRepositoryCredentials(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositoryCredentials", reflect.TypeOf((*MockServiceClient)(nil).ListRepositoryCredentials), varargs...)
+}
+
+// UpdateRepositoryCredentials mocks base method.
+func (m *MockServiceClient) UpdateRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.RepoCreds, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateRepositoryCredentials", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.RepoCreds)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRepositoryCredentials indicates an expected call of UpdateRepositoryCredentials.
+func (mr *MockServiceClientMockRecorder) UpdateRepositoryCredentials(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepositoryCredentials", reflect.TypeOf((*MockServiceClient)(nil).UpdateRepositoryCredentials), varargs...)
+}
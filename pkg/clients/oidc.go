@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/sync/singleflight"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+const (
+	errNoOIDC              = "no OIDC credentials given"
+	errGetOIDCClientSecret = "cannot get OIDC client secret"
+	errDiscoverOIDC        = "cannot discover OIDC provider"
+	errOIDCToken           = "cannot get OIDC access token"
+
+	// oidcDiscoveryTimeout bounds the discovery of an OIDC provider, which is
+	// shared by all reconciles waiting for it.
+	oidcDiscoveryTimeout = 10 * time.Second
+)
+
+// oidcTokenSources caches a token source per OIDC client, since a
+// CredentialSource is created for every reconcile.
+var oidcTokenSources = newTokenSourceCache()
+
+// OIDCCredentialSource obtains an access token with an OIDC client
+// credentials grant. Tokens are reused until shortly before they expire, then
+// a new one is requested.
+type OIDCCredentialSource struct {
+	Client          client.Client
+	Issuer          string
+	ClientID        string
+	ClientSecretRef xpv1.SecretKeySelector
+	Scopes          []string
+
+	// HTTPClient is used to reach the OIDC provider. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Resolve returns a valid access token.
+func (s *OIDCCredentialSource) Resolve(ctx context.Context) ([]byte, error) {
+	secret, err := (&SecretCredentialSource{Client: s.Client, Ref: s.ClientSecretRef}).Resolve(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetOIDCClientSecret)
+	}
+	ts, err := oidcTokenSources.get(s, string(secret))
+	if err != nil {
+		return nil, err
+	}
+	tok, err := ts.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, errOIDCToken)
+	}
+	return []byte(tok.AccessToken), nil
+}
+
+type tokenSourceCache struct {
+	discovery singleflight.Group
+
+	mu      sync.Mutex
+	sources map[string]cachedTokenSource
+}
+
+// A cachedTokenSource is the token source of an OIDC client for the secret
+// with the given hash.
+type cachedTokenSource struct {
+	hash string
+	ts   oauth2.TokenSource
+}
+
+func newTokenSourceCache() *tokenSourceCache {
+	return &tokenSourceCache{sources: map[string]cachedTokenSource{}}
+}
+
+// get returns the cached token source of the supplied client, discovering
+// the token endpoint of its issuer if there is none yet for the supplied
+// secret. The token source of a rotated secret is replaced.
+//
+// The discovery does not block the clients of other issuers, and concurrent
+// reconciles of the same client share it. It uses its own bounded context,
+// so that a reconcile giving up does not fail the others waiting for it.
+func (c *tokenSourceCache) get(s *OIDCCredentialSource, secret string) (oauth2.TokenSource, error) {
+	client := strings.Join([]string{s.Issuer, s.ClientID, strings.Join(s.Scopes, " ")}, "\x00")
+	h := sha256.Sum256([]byte(client + "\x00" + secret))
+	hash := hex.EncodeToString(h[:])
+
+	if ts, ok := c.lookup(client, hash); ok {
+		return ts, nil
+	}
+	ts, err, _ := c.discovery.Do(hash, func() (any, error) {
+		if ts, ok := c.lookup(client, hash); ok {
+			return ts, nil
+		}
+		ts, err := newOIDCTokenSource(s, secret)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.sources[client] = cachedTokenSource{hash: hash, ts: ts}
+		return ts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ts.(oauth2.TokenSource), nil
+}
+
+func (c *tokenSourceCache) lookup(client, hash string) (oauth2.TokenSource, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.sources[client]
+	if !ok || cached.hash != hash {
+		return nil, false
+	}
+	return cached.ts, true
+}
+
+// newOIDCTokenSource discovers the token endpoint of the issuer of the
+// supplied client and returns a token source using the supplied secret.
+func newOIDCTokenSource(s *OIDCCredentialSource, secret string) (oauth2.TokenSource, error) {
+	hc := s.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), oidcDiscoveryTimeout)
+	defer cancel()
+	p, err := oidc.NewProvider(oidc.ClientContext(ctx, hc), s.Issuer)
+	if err != nil {
+		return nil, errors.Wrap(err, errDiscoverOIDC)
+	}
+	cfg := &clientcredentials.Config{
+		ClientID:     s.ClientID,
+		ClientSecret: secret,
+		TokenURL:     p.Endpoint().TokenURL,
+		Scopes:       s.Scopes,
+	}
+	// The token source outlives the reconcile it is created in, so it must not
+	// use its context.
+	return cfg.TokenSource(oidc.ClientContext(context.Background(), hc)), nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+const (
+	testClientID     = "provider-argocd"
+	testClientSecret = "client-s3cr3t"
+)
+
+// newOIDCProvider serves OIDC discovery and a client credentials token
+// endpoint issuing tokens with the given lifetime in seconds.
+func newOIDCProvider(t *testing.T, expiresIn int) *httptest.Server {
+	t.Helper()
+	var issued int
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":         srv.URL,
+			"token_endpoint": srv.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != testClientID || secret != testClientSecret || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		issued++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d", issued),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		})
+	})
+	return srv
+}
+
+func TestOIDCCredentialSource(t *testing.T) {
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"clientSecret": []byte(testClientSecret)}
+			return nil
+		},
+	}
+
+	cases := map[string]struct {
+		expiresIn int
+		want      []string
+	}{
+		"ReusedUntilExpiry": {
+			expiresIn: 3600,
+			want:      []string{"token-1", "token-1"},
+		},
+		"RefreshedBeforeExpiry": {
+			// Tokens expiring within the next seconds are refreshed.
+			expiresIn: 5,
+			want:      []string{"token-1", "token-2"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := newOIDCProvider(t, tc.expiresIn)
+			src := &OIDCCredentialSource{
+				Client:          kube,
+				Issuer:          srv.URL,
+				ClientID:        testClientID,
+				ClientSecretRef: xpv1.SecretKeySelector{Key: "clientSecret"},
+				HTTPClient:      srv.Client(),
+			}
+
+			got := make([]string, 0, len(tc.want))
+			for range tc.want {
+				token, err := src.Resolve(context.Background())
+				if err != nil {
+					t.Fatalf("Resolve(...): %v", err)
+				}
+				got = append(got, string(token))
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Resolve(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTokenSourceCacheEvictsRotatedSecret(t *testing.T) {
+	srv := newOIDCProvider(t, 3600)
+	src := &OIDCCredentialSource{
+		Issuer:     srv.URL,
+		ClientID:   testClientID,
+		HTTPClient: srv.Client(),
+	}
+	cache := newTokenSourceCache()
+
+	for _, secret := range []string{"old", "rotated", "rotated"} {
+		if _, err := cache.get(src, secret); err != nil {
+			t.Fatalf("get(...): %v", err)
+		}
+	}
+	if diff := cmp.Diff(1, len(cache.sources)); diff != "" {
+		t.Errorf("cached token sources: -want, +got:\n%s", diff)
+	}
+
+	other := *src
+	other.ClientID = "other"
+	if _, err := cache.get(&other, "rotated"); err != nil {
+		t.Fatalf("get(...): %v", err)
+	}
+	if diff := cmp.Diff(2, len(cache.sources)); diff != "" {
+		t.Errorf("cached token sources of two clients: -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	errNewClient        = "cannot create argocd client"
+	errFmtNewService    = "cannot connect to argocd %s service"
+	errPooledClientGone = "argocd client was replaced, because the options of its ProviderConfig changed"
+)
+
+// A Pool shares the argocd client of each ProviderConfig between the
+// controllers of the provider, instead of dialing new connections on every
+// reconcile.
+//
+// The argocd client dials one gRPC connection per service, so each service
+// client is created once per ProviderConfig and shared by all controllers
+// using it. A client is replaced once the connection options of its
+// ProviderConfig change, e.g. because the auth token was rotated. Clients
+// are reference counted: the Pool references the current client of each
+// ProviderConfig, and every user references the client it uses. The
+// connections of a replaced client are closed once it is not referenced
+// anymore.
+type Pool struct {
+	newClient    func(opts *argocd.ClientOptions) (argocd.Client, error)
+	interceptors []grpc.UnaryClientInterceptor
+	caBundle     *CABundle
+
+	mu      sync.Mutex
+	clients map[string]*PooledClient
+}
+
+// A PoolOption configures a Pool.
+type PoolOption func(*Pool)
+
+// NewPool returns an empty Pool.
+func NewPool(o ...PoolOption) *Pool {
+	return newPool(argocd.NewClient, o...)
+}
+
+func newPool(newClient func(opts *argocd.ClientOptions) (argocd.Client, error), o ...PoolOption) *Pool {
+	p := &Pool{
+		newClient: newClient,
+		clients:   map[string]*PooledClient{},
+	}
+	for _, fn := range o {
+		fn(p)
+	}
+	return p
+}
+
+// Connect resolves the ProviderConfig of the supplied managed resource, see
+// GetConfig, and returns its client. The client is referenced until ctx is
+// done, so that it is not closed while the reconcile using it is running.
+func (p *Pool) Connect(ctx context.Context, c client.Client, mg resource.Managed) (*PooledClient, error) {
+	opts, err := GetConfig(ctx, c, mg, p.caBundle)
+	if err != nil {
+		return nil, err
+	}
+	pc, err := p.Client(mg.GetProviderConfigReference().Name, opts)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		pc.Release()
+	}()
+	return pc, nil
+}
+
+// Client returns a reference to the client of the supplied ProviderConfig.
+// The client is created if there is none yet for the connection options of
+// opts. The reference must be released with Release once it is not used
+// anymore.
+func (p *Pool) Client(providerConfig string, opts *argocd.ClientOptions) (*PooledClient, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := connectionKeyOf(opts)
+	c, ok := p.clients[providerConfig]
+	if !ok || c.key != key {
+		cl, err := p.newClient(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, errNewClient)
+		}
+		// The new client starts with the reference of the Pool.
+		p.clients[providerConfig] = &PooledClient{client: cl, key: key, interceptors: p.interceptors, refs: 1, services: map[string]any{}}
+		if ok {
+			c.Release()
+		}
+		c = p.clients[providerConfig]
+	}
+	c.acquire()
+	return c, nil
+}
+
+// A connectionKey holds the options that the connections of an argocd
+// client are dialed with. Clients with the same key are interchangeable.
+type connectionKey struct {
+	serverAddr        string
+	plainText         bool
+	insecure          bool
+	certFile          string
+	clientCertFile    string
+	clientCertKeyFile string
+	grpcWeb           bool
+	grpcWebRootPath   string
+	userAgent         string
+	headers           string
+	// The argocd client passes the auth token as credentials of the
+	// connections it dials, so a new token needs new connections. Tokens are
+	// reused until they expire, see OIDCCredentialSource.
+	authToken string
+}
+
+func connectionKeyOf(opts *argocd.ClientOptions) connectionKey {
+	return connectionKey{
+		serverAddr:        opts.ServerAddr,
+		plainText:         opts.PlainText,
+		insecure:          opts.Insecure,
+		certFile:          opts.CertFile,
+		clientCertFile:    opts.ClientCertFile,
+		clientCertKeyFile: opts.ClientCertKeyFile,
+		grpcWeb:           opts.GRPCWeb,
+		grpcWebRootPath:   opts.GRPCWebRootPath,
+		userAgent:         opts.UserAgent,
+		headers:           strings.Join(opts.Headers, "\n"),
+		authToken:         opts.AuthToken,
+	}
+}
+
+// A PooledClient is the argocd client of a ProviderConfig, shared through a
+// Pool. Its service clients are created by Service.
+type PooledClient struct {
+	client       argocd.Client
+	key          connectionKey
+	interceptors []grpc.UnaryClientInterceptor
+
+	mu       sync.Mutex
+	refs     int
+	closed   bool
+	services map[string]any
+	closers  []io.Closer
+}
+
+func (c *PooledClient) acquire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refs++
+}
+
+// Release drops a reference to the client, and closes its connections once
+// it is not referenced anymore.
+func (c *PooledClient) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refs--
+	if c.refs > 0 {
+		return
+	}
+	c.closed = true
+	for _, closer := range c.closers {
+		_ = closer.Close()
+	}
+	c.closers = nil
+	c.services = nil
+}
+
+// Service returns the service client with the supplied name of the pooled
+// client. It is created by newService on first use and shared afterwards.
+// newService is passed the interceptors of the Pool, which the service client
+// must run around its calls, see Invoke.
+func Service[T any](c *PooledClient, name string, newService func(argocd.Client, []grpc.UnaryClientInterceptor) (io.Closer, T, error)) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var svc T
+	if c.closed {
+		return svc, errors.New(errPooledClientGone)
+	}
+	if s, ok := c.services[name]; ok {
+		return s.(T), nil
+	}
+	closer, svc, err := newService(c.client, c.interceptors)
+	if err != nil {
+		return svc, errors.Wrapf(err, errFmtNewService, name)
+	}
+	c.services[name] = svc
+	c.closers = append(c.closers, closer)
+	return svc, nil
+}
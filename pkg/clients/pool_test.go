@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	argocd "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// fakeConn counts how often it was closed.
+type fakeConn struct{ closed int }
+
+func (c *fakeConn) Close() error {
+	c.closed++
+	return nil
+}
+
+// fakeClient is an argocd client that records the connections it dials.
+type fakeClient struct {
+	argocd.Client
+	conns []*fakeConn
+}
+
+func (c *fakeClient) dial() (io.Closer, *fakeConn, error) {
+	conn := &fakeConn{}
+	c.conns = append(c.conns, conn)
+	return conn, conn, nil
+}
+
+// fakeClients returns a Pool that creates fakeClients, and the clients it
+// created.
+func fakeClients() (*Pool, *[]*fakeClient) {
+	created := &[]*fakeClient{}
+	return newPool(func(_ *argocd.ClientOptions) (argocd.Client, error) {
+		c := &fakeClient{}
+		*created = append(*created, c)
+		return c, nil
+	}), created
+}
+
+func dialService(c argocd.Client, _ []grpc.UnaryClientInterceptor) (io.Closer, *fakeConn, error) {
+	return c.(*fakeClient).dial()
+}
+
+func TestPoolSharesConnection(t *testing.T) {
+	pool, created := fakeClients()
+	opts := &argocd.ClientOptions{ServerAddr: "argocd.example.com", AuthToken: "token"}
+
+	apps, err := pool.Client("default", opts)
+	if err != nil {
+		t.Fatalf("pool.Client(...): %v", err)
+	}
+	projects, err := pool.Client("default", &argocd.ClientOptions{ServerAddr: "argocd.example.com", AuthToken: "token"})
+	if err != nil {
+		t.Fatalf("pool.Client(...): %v", err)
+	}
+	if apps != projects {
+		t.Errorf("pool.Client(...): users of the same ProviderConfig got different clients")
+	}
+
+	for _, c := range []*PooledClient{apps, projects, apps} {
+		if _, err := Service(c, "application", dialService); err != nil {
+			t.Fatalf("Service(...): %v", err)
+		}
+	}
+	if diff := cmp.Diff(1, len(*created)); diff != "" {
+		t.Errorf("clients created: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(1, len((*created)[0].conns)); diff != "" {
+		t.Errorf("connections dialed: -want, +got:\n%s", diff)
+	}
+
+	apps.Release()
+	projects.Release()
+	if diff := cmp.Diff(0, (*created)[0].conns[0].closed); diff != "" {
+		t.Errorf("current connection closed after its users released it: -want, +got:\n%s", diff)
+	}
+
+	other, err := pool.Client("other", opts)
+	if err != nil {
+		t.Fatalf("pool.Client(...): %v", err)
+	}
+	if other == apps {
+		t.Errorf("pool.Client(...): different ProviderConfigs got the same client")
+	}
+}
+
+func TestPoolReplacesClient(t *testing.T) {
+	pool, created := fakeClients()
+
+	old, _ := pool.Client("default", &argocd.ClientOptions{AuthToken: "old"})
+	concurrent, _ := pool.Client("default", &argocd.ClientOptions{AuthToken: "old"})
+	if _, err := Service(old, "application", dialService); err != nil {
+		t.Fatalf("Service(...): %v", err)
+	}
+	conn := (*created)[0].conns[0]
+
+	rotated, _ := pool.Client("default", &argocd.ClientOptions{AuthToken: "rotated"})
+	if rotated == old {
+		t.Fatalf("pool.Client(...): client was not replaced after the options changed")
+	}
+	if c, _ := pool.Client("default", &argocd.ClientOptions{AuthToken: "rotated"}); c != rotated {
+		t.Errorf("pool.Client(...): users of the same ProviderConfig got different clients")
+	}
+
+	old.Release()
+	if diff := cmp.Diff(0, conn.closed); diff != "" {
+		t.Errorf("replaced connection closed while it is referenced: -want, +got:\n%s", diff)
+	}
+	if _, err := Service(concurrent, "application", dialService); err != nil {
+		t.Errorf("Service(...) of a replaced client that is still referenced: %v", err)
+	}
+
+	concurrent.Release()
+	if diff := cmp.Diff(1, conn.closed); diff != "" {
+		t.Errorf("replaced connection closed once it is not referenced anymore: -want, +got:\n%s", diff)
+	}
+
+	_, err := Service(old, "application", dialService)
+	if diff := cmp.Diff(errors.New(errPooledClientGone), err, test.EquateErrors()); diff != "" {
+		t.Errorf("Service(...) of a released client: -want error, +got error:\n%s", diff)
+	}
+}
+
+func TestPoolKeepsClient(t *testing.T) {
+	pool, _ := fakeClients()
+	opts := &argocd.ClientOptions{ServerAddr: "argocd.example.com", AuthToken: "token"}
+
+	c, _ := pool.Client("default", opts)
+	same, _ := pool.Client("default", &argocd.ClientOptions{
+		ServerAddr:    "argocd.example.com",
+		AuthToken:     "token",
+		ConfigPath:    "/dev/null",
+		KubeOverrides: &clientcmd.ConfigOverrides{},
+	})
+	if same != c {
+		t.Errorf("pool.Client(...): client was replaced although its connection options did not change")
+	}
+}
+
+func TestPoolPassesInterceptors(t *testing.T) {
+	var intercepted []string
+	pool := newPool(func(_ *argocd.ClientOptions) (argocd.Client, error) {
+		return &fakeClient{}, nil
+	}, WithUnaryInterceptors(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		intercepted = append(intercepted, method)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}))
+
+	c, err := pool.Client("default", &argocd.ClientOptions{})
+	if err != nil {
+		t.Fatalf("pool.Client(...): %v", err)
+	}
+	interceptors, err := Service(c, "application", func(_ argocd.Client, i []grpc.UnaryClientInterceptor) (io.Closer, []grpc.UnaryClientInterceptor, error) {
+		return &fakeConn{}, i, nil
+	})
+	if err != nil {
+		t.Fatalf("Service(...): %v", err)
+	}
+	get := func(_ context.Context, in string, _ ...grpc.CallOption) (string, error) { return in, nil }
+	if _, err := Invoke(context.Background(), interceptors, "/application.ApplicationService/Get", "a", get); err != nil {
+		t.Fatalf("Invoke(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{"/application.ApplicationService/Get"}, intercepted); diff != "" {
+		t.Errorf("intercepted calls: -want, +got:\n%s", diff)
+	}
+}
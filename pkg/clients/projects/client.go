@@ -2,6 +2,7 @@ package projects
 
 import (
 	"context"
+	"io"
 	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
@@ -9,6 +10,8 @@ import (
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 
 	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 )
 
 const (
@@ -25,12 +28,52 @@ type ProjectServiceClient interface {
 	Update(ctx context.Context, in *project.ProjectUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.AppProject, error)
 	// Delete deletes a project
 	Delete(ctx context.Context, in *project.ProjectQuery, opts ...grpc.CallOption) (*project.EmptyResponse, error)
+	// CreateToken creates a token of a project role
+	CreateToken(ctx context.Context, in *project.ProjectTokenCreateRequest, opts ...grpc.CallOption) (*project.ProjectTokenResponse, error)
+	// DeleteToken revokes a token of a project role
+	DeleteToken(ctx context.Context, in *project.ProjectTokenDeleteRequest, opts ...grpc.CallOption) (*project.EmptyResponse, error)
+}
+
+// NewProjectServiceClient returns the project service client of the supplied pooled client.
+func NewProjectServiceClient(c *clients.PooledClient) (project.ProjectServiceClient, error) {
+	return clients.Service(c, "project", func(c apiclient.Client, interceptors []grpc.UnaryClientInterceptor) (io.Closer, project.ProjectServiceClient, error) {
+		closer, repoIf, err := c.NewProjectClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return closer, &interceptedClient{repoIf, interceptors}, nil
+	})
+}
+
+// interceptedClient runs the interceptors of its Pool around the calls made
+// by the provider.
+type interceptedClient struct {
+	project.ProjectServiceClient
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+func (c *interceptedClient) Create(ctx context.Context, in *project.ProjectCreateRequest, opts ...grpc.CallOption) (*v1alpha1.AppProject, error) {
+	return clients.Invoke(ctx, c.interceptors, "/project.ProjectService/Create", in, c.ProjectServiceClient.Create, opts...)
+}
+
+func (c *interceptedClient) Get(ctx context.Context, in *project.ProjectQuery, opts ...grpc.CallOption) (*v1alpha1.AppProject, error) {
+	return clients.Invoke(ctx, c.interceptors, "/project.ProjectService/Get", in, c.ProjectServiceClient.Get, opts...)
+}
+
+func (c *interceptedClient) Update(ctx context.Context, in *project.ProjectUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.AppProject, error) {
+	return clients.Invoke(ctx, c.interceptors, "/project.ProjectService/Update", in, c.ProjectServiceClient.Update, opts...)
+}
+
+func (c *interceptedClient) Delete(ctx context.Context, in *project.ProjectQuery, opts ...grpc.CallOption) (*project.EmptyResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/project.ProjectService/Delete", in, c.ProjectServiceClient.Delete, opts...)
+}
+
+func (c *interceptedClient) CreateToken(ctx context.Context, in *project.ProjectTokenCreateRequest, opts ...grpc.CallOption) (*project.ProjectTokenResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/project.ProjectService/CreateToken", in, c.ProjectServiceClient.CreateToken, opts...)
 }
 
-// NewProjectServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
-func NewProjectServiceClient(clientOpts *apiclient.ClientOptions) project.ProjectServiceClient {
-	_, repoIf := apiclient.NewClientOrDie(clientOpts).NewProjectClientOrDie()
-	return repoIf
+func (c *interceptedClient) DeleteToken(ctx context.Context, in *project.ProjectTokenDeleteRequest, opts ...grpc.CallOption) (*project.EmptyResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/project.ProjectService/DeleteToken", in, c.ProjectServiceClient.DeleteToken, opts...)
 }
 
 // IsErrorProjectNotFound helper function to test for errorProjectNotFound error.
@@ -0,0 +1,47 @@
+package projects
+
+import (
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// IsSyncWindowsEqual compares sync windows regardless of their order, which
+// has no effect in ArgoCD.
+func IsSyncWindowsEqual(a, b v1alpha1.SyncWindows) bool {
+	return cmp.Equal(a, b, cmpopts.EquateEmpty(), cmpopts.SortSlices(lessSyncWindow))
+}
+
+// lessSyncWindow orders sync windows by kind, schedule, duration,
+// applications, namespaces, clusters, manual sync and time zone.
+func lessSyncWindow(a, b *v1alpha1.SyncWindow) bool {
+	ka, kb := syncWindowKey(a), syncWindowKey(b)
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return ka[i] < kb[i]
+		}
+	}
+	return false
+}
+
+func syncWindowKey(w *v1alpha1.SyncWindow) [8]string {
+	if w == nil {
+		return [8]string{}
+	}
+	manualSync := "false"
+	if w.ManualSync {
+		manualSync = "true"
+	}
+	return [8]string{
+		w.Kind,
+		w.Schedule,
+		w.Duration,
+		strings.Join(w.Applications, "\n"),
+		strings.Join(w.Namespaces, "\n"),
+		strings.Join(w.Clusters, "\n"),
+		manualSync,
+		w.TimeZone,
+	}
+}
@@ -0,0 +1,74 @@
+package projects
+
+import (
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+)
+
+func TestIsSyncWindowsEqual(t *testing.T) {
+	const schedule = "10 1 * * *"
+	allow := v1alpha1.SyncWindow{Kind: "allow", Schedule: schedule, Duration: "1h", Applications: []string{"*"}}
+	deny := v1alpha1.SyncWindow{Kind: "deny", Schedule: schedule, Duration: "1h", Applications: []string{"app"}}
+	manualDeny := v1alpha1.SyncWindow{Kind: "deny", Schedule: schedule, Duration: "1h", Applications: []string{"app"}, ManualSync: true}
+
+	cases := map[string]struct {
+		a    v1alpha1.SyncWindows
+		b    v1alpha1.SyncWindows
+		want bool
+	}{
+		"Equal": {
+			a:    v1alpha1.SyncWindows{&allow, &deny},
+			b:    v1alpha1.SyncWindows{&allow, &deny},
+			want: true,
+		},
+		"Reordered": {
+			a:    v1alpha1.SyncWindows{&allow, &deny},
+			b:    v1alpha1.SyncWindows{&deny, &allow},
+			want: true,
+		},
+		"EmptyListsEqual": {
+			a:    v1alpha1.SyncWindows{{Kind: "allow", Schedule: schedule, Duration: "1h", Namespaces: []string{}}},
+			b:    v1alpha1.SyncWindows{{Kind: "allow", Schedule: schedule, Duration: "1h"}},
+			want: true,
+		},
+		"DurationChanged": {
+			a:    v1alpha1.SyncWindows{&allow, &deny},
+			b:    v1alpha1.SyncWindows{&deny, {Kind: "allow", Schedule: schedule, Duration: "2h", Applications: []string{"*"}}},
+			want: false,
+		},
+		"ManualSyncChanged": {
+			a:    v1alpha1.SyncWindows{&manualDeny, &allow},
+			b:    v1alpha1.SyncWindows{&allow, &deny},
+			want: false,
+		},
+		"TimeZoneChanged": {
+			a:    v1alpha1.SyncWindows{&allow},
+			b:    v1alpha1.SyncWindows{{Kind: "allow", Schedule: schedule, Duration: "1h", Applications: []string{"*"}, TimeZone: "Europe/Berlin"}},
+			want: false,
+		},
+		"KindsSwapped": {
+			a:    v1alpha1.SyncWindows{&allow, &deny},
+			b:    v1alpha1.SyncWindows{{Kind: "deny", Schedule: schedule, Duration: "1h", Applications: []string{"*"}}, {Kind: "allow", Schedule: schedule, Duration: "1h", Applications: []string{"app"}}},
+			want: false,
+		},
+		"DuplicateWindow": {
+			a:    v1alpha1.SyncWindows{&allow, &allow},
+			b:    v1alpha1.SyncWindows{&allow, &deny},
+			want: false,
+		},
+		"WindowAdded": {
+			a:    v1alpha1.SyncWindows{&allow, &deny},
+			b:    v1alpha1.SyncWindows{&allow},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsSyncWindowsEqual(tc.a, tc.b); got != tc.want {
+				t.Errorf("IsSyncWindowsEqual(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package repocreds
+
+import (
+	"context"
+	"io"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+
+	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+)
+
+// ServiceClient wraps the functions to connect to argocd repository credential templates
+type ServiceClient interface {
+	// ListRepositoryCredentials gets a list of all configured repository credential sets
+	ListRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsQuery, opts ...grpc.CallOption) (*v1alpha1.RepoCredsList, error)
+	// CreateRepositoryCredentials creates a new repository credential set
+	CreateRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsCreateRequest, opts ...grpc.CallOption) (*v1alpha1.RepoCreds, error)
+	// UpdateRepositoryCredentials updates a repository credential set
+	UpdateRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.RepoCreds, error)
+	// DeleteRepositoryCredentials deletes a repository credential set from the configuration
+	DeleteRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsDeleteRequest, opts ...grpc.CallOption) (*repocreds.RepoCredsResponse, error)
+}
+
+// NewRepoCredsServiceClient returns the repocreds service client of the supplied pooled client.
+func NewRepoCredsServiceClient(c *clients.PooledClient) (repocreds.RepoCredsServiceClient, error) {
+	return clients.Service(c, "repocreds", func(c apiclient.Client, interceptors []grpc.UnaryClientInterceptor) (io.Closer, repocreds.RepoCredsServiceClient, error) {
+		closer, repoCredsIf, err := c.NewRepoCredsClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return closer, &interceptedClient{repoCredsIf, interceptors}, nil
+	})
+}
+
+// interceptedClient runs the interceptors of its Pool around the calls made
+// by the provider.
+type interceptedClient struct {
+	repocreds.RepoCredsServiceClient
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+func (c *interceptedClient) ListRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsQuery, opts ...grpc.CallOption) (*v1alpha1.RepoCredsList, error) {
+	return clients.Invoke(ctx, c.interceptors, "/repocreds.RepoCredsService/ListRepositoryCredentials", in, c.RepoCredsServiceClient.ListRepositoryCredentials, opts...)
+}
+
+func (c *interceptedClient) CreateRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsCreateRequest, opts ...grpc.CallOption) (*v1alpha1.RepoCreds, error) {
+	return clients.Invoke(ctx, c.interceptors, "/repocreds.RepoCredsService/CreateRepositoryCredentials", in, c.RepoCredsServiceClient.CreateRepositoryCredentials, opts...)
+}
+
+func (c *interceptedClient) UpdateRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.RepoCreds, error) {
+	return clients.Invoke(ctx, c.interceptors, "/repocreds.RepoCredsService/UpdateRepositoryCredentials", in, c.RepoCredsServiceClient.UpdateRepositoryCredentials, opts...)
+}
+
+func (c *interceptedClient) DeleteRepositoryCredentials(ctx context.Context, in *repocreds.RepoCredsDeleteRequest, opts ...grpc.CallOption) (*repocreds.RepoCredsResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/repocreds.RepoCredsService/DeleteRepositoryCredentials", in, c.RepoCredsServiceClient.DeleteRepositoryCredentials, opts...)
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"io"
 	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
@@ -9,6 +10,8 @@ import (
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 
 	"google.golang.org/grpc"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 )
 
 const (
@@ -29,10 +32,42 @@ type RepositoryServiceClient interface {
 	DeleteRepository(ctx context.Context, in *repository.RepoQuery, opts ...grpc.CallOption) (*repository.RepoResponse, error)
 }
 
-// NewRepositoryServiceClient creates a new API client from a set of config options, or fails fatally if the new client creation fails.
-func NewRepositoryServiceClient(clientOpts *apiclient.ClientOptions) repository.RepositoryServiceClient {
-	_, repoIf := apiclient.NewClientOrDie(clientOpts).NewRepoClientOrDie()
-	return repoIf
+// NewRepositoryServiceClient returns the repository service client of the supplied pooled client.
+func NewRepositoryServiceClient(c *clients.PooledClient) (repository.RepositoryServiceClient, error) {
+	return clients.Service(c, "repository", func(c apiclient.Client, interceptors []grpc.UnaryClientInterceptor) (io.Closer, repository.RepositoryServiceClient, error) {
+		closer, repoIf, err := c.NewRepoClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return closer, &interceptedClient{repoIf, interceptors}, nil
+	})
+}
+
+// interceptedClient runs the interceptors of its Pool around the calls made
+// by the provider.
+type interceptedClient struct {
+	repository.RepositoryServiceClient
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+func (c *interceptedClient) Get(ctx context.Context, in *repository.RepoQuery, opts ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	return clients.Invoke(ctx, c.interceptors, "/repository.RepositoryService/Get", in, c.RepositoryServiceClient.Get, opts...)
+}
+
+func (c *interceptedClient) ListRepositories(ctx context.Context, in *repository.RepoQuery, opts ...grpc.CallOption) (*v1alpha1.RepositoryList, error) {
+	return clients.Invoke(ctx, c.interceptors, "/repository.RepositoryService/ListRepositories", in, c.RepositoryServiceClient.ListRepositories, opts...)
+}
+
+func (c *interceptedClient) CreateRepository(ctx context.Context, in *repository.RepoCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	return clients.Invoke(ctx, c.interceptors, "/repository.RepositoryService/CreateRepository", in, c.RepositoryServiceClient.CreateRepository, opts...)
+}
+
+func (c *interceptedClient) UpdateRepository(ctx context.Context, in *repository.RepoUpdateRequest, opts ...grpc.CallOption) (*v1alpha1.Repository, error) {
+	return clients.Invoke(ctx, c.interceptors, "/repository.RepositoryService/UpdateRepository", in, c.RepositoryServiceClient.UpdateRepository, opts...)
+}
+
+func (c *interceptedClient) DeleteRepository(ctx context.Context, in *repository.RepoQuery, opts ...grpc.CallOption) (*repository.RepoResponse, error) {
+	return clients.Invoke(ctx, c.interceptors, "/repository.RepositoryService/DeleteRepository", in, c.RepositoryServiceClient.DeleteRepository, opts...)
 }
 
 // IsErrorRepositoryNotFound helper function to test for errorRepositoryNotFound error.
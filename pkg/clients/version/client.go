@@ -0,0 +1,32 @@
+package version
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ServiceClient wraps the functions to query the argocd server version
+type ServiceClient interface {
+	// Version returns version information of the argocd server
+	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*version.VersionMessage, error)
+}
+
+// NewVersionServiceClient creates a new API client from a set of config options.
+// Unlike the other service clients it returns an error instead of failing fatally,
+// since it is used to diagnose connection problems.
+func NewVersionServiceClient(clientOpts *apiclient.ClientOptions) (ServiceClient, error) {
+	c, err := apiclient.NewClient(clientOpts)
+	if err != nil {
+		return nil, err
+	}
+	_, versionIf, err := c.NewVersionClient()
+	if err != nil {
+		return nil, err
+	}
+	return versionIf, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connection contains helpers to publish connection details.
+package connection
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// NewAPISecretPublisher returns a managed.APISecretPublisher that renames keys
+// like a NewKeyMappingPublisher.
+func NewAPISecretPublisher(c client.Client, ot runtime.ObjectTyper) managed.ConnectionPublisher {
+	return NewKeyMappingPublisher(managed.NewAPISecretPublisher(c, ot))
+}
+
+// A KeyMapper maps the keys of its connection details to the keys they are
+// published as.
+type KeyMapper interface {
+	GetConnectionDetailsKeys() map[string]string
+}
+
+// NewKeyMappingPublisher wraps the supplied publisher, so that the keys of the
+// connection details of a KeyMapper are renamed before they are published or
+// unpublished.
+func NewKeyMappingPublisher(p managed.ConnectionPublisher) managed.ConnectionPublisher {
+	return &keyMappingPublisher{publisher: p}
+}
+
+type keyMappingPublisher struct {
+	publisher managed.ConnectionPublisher
+}
+
+func (p *keyMappingPublisher) PublishConnection(ctx context.Context, so resource.ConnectionSecretOwner, c managed.ConnectionDetails) (bool, error) {
+	return p.publisher.PublishConnection(ctx, so, mapKeys(so, c))
+}
+
+func (p *keyMappingPublisher) UnpublishConnection(ctx context.Context, so resource.ConnectionSecretOwner, c managed.ConnectionDetails) error {
+	return p.publisher.UnpublishConnection(ctx, so, mapKeys(so, c))
+}
+
+// mapKeys returns the supplied connection details with their keys renamed as
+// configured by the owner. A renamed key replaces a key of the same name that
+// is not renamed. Keys are renamed in order, so that the last of several keys
+// renamed to the same key wins.
+func mapKeys(so resource.ConnectionSecretOwner, c managed.ConnectionDetails) managed.ConnectionDetails {
+	km, ok := so.(KeyMapper)
+	if !ok || len(km.GetConnectionDetailsKeys()) == 0 || c == nil {
+		return c
+	}
+	keys := km.GetConnectionDetailsKeys()
+
+	out := make(managed.ConnectionDetails, len(c))
+	renamed := make([]string, 0, len(c))
+	for k, v := range c {
+		if keys[k] != "" {
+			renamed = append(renamed, k)
+			continue
+		}
+		out[k] = v
+	}
+	sort.Strings(renamed)
+	for _, k := range renamed {
+		out[keys[k]] = c[k]
+	}
+	return out
+}
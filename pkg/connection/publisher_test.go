@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+// keyMappingManaged is a managed resource that renames its connection details.
+type keyMappingManaged struct {
+	fake.Managed
+	keys map[string]string
+}
+
+func (m *keyMappingManaged) GetConnectionDetailsKeys() map[string]string {
+	return m.keys
+}
+
+func TestKeyMappingPublisher(t *testing.T) {
+	details := managed.ConnectionDetails{
+		"ci.deploy":  []byte("t1"),
+		"ci.release": []byte("t2"),
+		"TOKEN":      []byte("t3"),
+	}
+
+	cases := map[string]struct {
+		so   resource.ConnectionSecretOwner
+		want managed.ConnectionDetails
+	}{
+		"NoKeyMapper": {
+			so:   &fake.Managed{},
+			want: details,
+		},
+		"NoKeys": {
+			so:   &keyMappingManaged{},
+			want: details,
+		},
+		"Renamed": {
+			so: &keyMappingManaged{keys: map[string]string{"ci.deploy": "DEPLOY_TOKEN", "ci.missing": "MISSING"}},
+			want: managed.ConnectionDetails{
+				"DEPLOY_TOKEN": []byte("t1"),
+				"ci.release":   []byte("t2"),
+				"TOKEN":        []byte("t3"),
+			},
+		},
+		"RenamedReplacesKey": {
+			so: &keyMappingManaged{keys: map[string]string{"ci.deploy": "TOKEN"}},
+			want: managed.ConnectionDetails{
+				"TOKEN":      []byte("t1"),
+				"ci.release": []byte("t2"),
+			},
+		},
+		"LastRenamedWins": {
+			so: &keyMappingManaged{keys: map[string]string{"ci.deploy": "CI", "ci.release": "CI"}},
+			want: managed.ConnectionDetails{
+				"CI":    []byte("t2"),
+				"TOKEN": []byte("t3"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var published, unpublished managed.ConnectionDetails
+			p := NewKeyMappingPublisher(managed.ConnectionPublisherFns{
+				PublishConnectionFn: func(_ context.Context, _ resource.ConnectionSecretOwner, c managed.ConnectionDetails) (bool, error) {
+					published = c
+					return true, nil
+				},
+				UnpublishConnectionFn: func(_ context.Context, _ resource.ConnectionSecretOwner, c managed.ConnectionDetails) error {
+					unpublished = c
+					return nil
+				},
+			})
+
+			if _, err := p.PublishConnection(context.Background(), tc.so, details); err != nil {
+				t.Fatalf("PublishConnection(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, published); diff != "" {
+				t.Errorf("PublishConnection(...): -want, +got:\n%s", diff)
+			}
+			if err := p.UnpublishConnection(context.Background(), tc.so, details); err != nil {
+				t.Fatalf("UnpublishConnection(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, unpublished); diff != "" {
+				t.Errorf("UnpublishConnection(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
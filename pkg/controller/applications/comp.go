@@ -1,6 +1,9 @@
 package applications
 
 import (
+	"sort"
+	"strings"
+
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -8,6 +11,11 @@ import (
 	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
 )
 
+const (
+	argoprojDomain = "argoproj.io"
+	headRevision   = "HEAD"
+)
+
 // IsApplicationUpToDate converts ApplicationParameters to its ArgoCD Counterpart and returns if they equal
 func IsApplicationUpToDate(cr *v1alpha1.ApplicationParameters, remote *argocdv1alpha1.Application) bool { // nolint:gocyclo
 	converter := v1alpha1.ConverterImpl{}
@@ -18,5 +26,158 @@ func IsApplicationUpToDate(cr *v1alpha1.ApplicationParameters, remote *argocdv1a
 		// the unexported fields should not bother here, since we don't copy them or write them
 		cmpopts.IgnoreUnexported(argocdv1alpha1.ApplicationDestination{}),
 	}
-	return cmp.Equal(*cluster, remote.Spec, opts...)
+	remoteSpec := remote.Spec.DeepCopy()
+	normalizeTargetRevisions(cluster)
+	normalizeTargetRevisions(remoteSpec)
+	normalizeSourcesOrder(cluster)
+	normalizeSourcesOrder(remoteSpec)
+
+	return cmp.Equal(*cluster, *remoteSpec, opts...) &&
+		hasLabels(remote.Labels, cr.Labels)
+}
+
+// normalizeTargetRevisions rewrites equivalent target revisions to a single
+// form, so that they are not reported as drift:
+//   - an empty targetRevision of a Git source equals HEAD
+//
+// Helm chart sources are left untouched, since HEAD is no valid chart version.
+// Only the desired revisions are compared, so a branch is never compared with
+// the commit it resolved to.
+func normalizeTargetRevisions(spec *argocdv1alpha1.ApplicationSpec) {
+	if spec.Source != nil {
+		normalizeTargetRevision(spec.Source)
+	}
+	for i := range spec.Sources {
+		normalizeTargetRevision(&spec.Sources[i])
+	}
+}
+
+func normalizeTargetRevision(src *argocdv1alpha1.ApplicationSource) {
+	if src.Chart == "" && src.TargetRevision == "" {
+		src.TargetRevision = headRevision
+	}
+}
+
+// normalizeSourcesOrder moves the sources that only provide a ref for the
+// value files of other sources to the front, ordered by ref. ArgoCD looks up
+// refs by name, so their position has no meaning. The order of the sources
+// rendering manifests is kept, since it decides which source wins if several
+// render the same resource, so reordering them is reported as drift.
+func normalizeSourcesOrder(spec *argocdv1alpha1.ApplicationSpec) {
+	if len(spec.Sources) < 2 {
+		return
+	}
+	sources := make(argocdv1alpha1.ApplicationSources, 0, len(spec.Sources))
+	for _, src := range spec.Sources {
+		if isRefOnlySource(src) {
+			sources = append(sources, src)
+		}
+	}
+	sort.SliceStable(sources, func(i, j int) bool { return sources[i].Ref < sources[j].Ref })
+	for _, src := range spec.Sources {
+		if !isRefOnlySource(src) {
+			sources = append(sources, src)
+		}
+	}
+	spec.Sources = sources
+}
+
+// isRefOnlySource returns true if the source renders no manifests itself but
+// is only referenced by other sources.
+func isRefOnlySource(src argocdv1alpha1.ApplicationSource) bool {
+	return src.Ref != "" && src.Path == "" && src.Chart == ""
+}
+
+// hasLabels returns true if labels contains all desired labels. Other labels,
+// like the ones owned by ArgoCD or set by other tools, are not managed by the
+// provider and thus ignored.
+func hasLabels(labels, desired map[string]string) bool {
+	for k, v := range withoutArgoCDLabels(desired) {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+	return true
+}
+
+// hasRemovedLabels returns true if labels contains a managed label that is no
+// longer desired.
+func hasRemovedLabels(labels, desired map[string]string, managed []string) bool {
+	desired = withoutArgoCDLabels(desired)
+	for _, k := range managed {
+		if _, ok := desired[k]; ok {
+			continue
+		}
+		if _, ok := labels[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// managedLabels returns the sorted keys of the labels managed by the
+// provider: the desired ones and the previously managed ones that are still
+// set, so that they are removed by the next update.
+func managedLabels(labels, desired map[string]string, managed []string) []string {
+	keys := make(map[string]struct{}, len(desired)+len(managed))
+	for k := range withoutArgoCDLabels(desired) {
+		keys[k] = struct{}{}
+	}
+	for _, k := range managed {
+		if _, ok := labels[k]; ok {
+			keys[k] = struct{}{}
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(keys))
+	for k := range keys {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// mergeLabels returns the supplied current labels without the managed labels
+// that are no longer desired, and with the desired labels set on top. The
+// current labels are not modified.
+func mergeLabels(current, desired map[string]string, managed []string) map[string]string {
+	desired = withoutArgoCDLabels(desired)
+	res := make(map[string]string, len(current)+len(desired))
+	for k, v := range current {
+		res[k] = v
+	}
+	for _, k := range managed {
+		delete(res, k)
+	}
+	for k, v := range desired {
+		res[k] = v
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// withoutArgoCDLabels returns the given labels without the ones owned by
+// ArgoCD, which are never set by the provider.
+func withoutArgoCDLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if isArgoCDLabel(k) {
+			continue
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// isArgoCDLabel returns true if the label key is prefixed with an argoproj.io domain.
+func isArgoCDLabel(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	return found && (prefix == argoprojDomain || strings.HasSuffix(prefix, "."+argoprojDomain))
 }
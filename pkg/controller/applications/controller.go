@@ -18,16 +18,24 @@ package applications
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
 
-	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/gobwas/glob"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -36,8 +44,15 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applications"
+	"github.com/crossplane-contrib/provider-argocd/pkg/connection"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/options"
+	"github.com/crossplane-contrib/provider-argocd/pkg/jitter"
+	"github.com/crossplane-contrib/provider-argocd/pkg/metrics"
+	"github.com/crossplane-contrib/provider-argocd/pkg/shard"
+	"github.com/crossplane-contrib/provider-argocd/pkg/tracing"
 )
 
 const (
@@ -47,30 +62,58 @@ const (
 	errCreateFailed     = "cannot create Argocd application"
 	errUpdateFailed     = "cannot update Argocd application"
 	errDeleteFailed     = "cannot delete Argocd application"
+	errChartAndPath     = "application source must not set both chart and path"
+	errFmtDirectoryGlob = "application source directory %s %q is no valid glob"
+	warnFmtInfoURL      = "info %q is rendered as a link, but %q is no valid URL"
+	errEmptyPluginName  = "application source plugin name must not be empty, omit it to discover the plugin"
+
+	errTerminateOperation = "cannot terminate operation of Argocd application"
+	errSyncAfterCreate    = "cannot sync created Argocd application"
+	warnFmtSkipValidation = "application is not validated by Argocd, unless annotation %s is removed"
+
+	errFmtProjectMismatch = "application is in project %q instead of %q: %s"
+	errFmtOwnedByAppSet   = "application is owned by ApplicationSet %q and not managed, unless annotation %s is \"true\""
+
+	kindApplicationSet = "ApplicationSet"
+
+	reasonSyncAfterCreate event.Reason = "SyncAfterCreate"
+
+	// connectionKeySyncedRevision is the connection detail holding the
+	// revision the application is synced to, e.g. a git commit SHA.
+	connectionKeySyncedRevision = "syncedRevision"
 )
 
 // SetupApplication adds a controller that reconciles applications.
-func SetupApplication(mgr ctrl.Manager, l logging.Logger) error {
+// Applications without an appNamespace are scoped to the namespace of the
+// options, if it is not empty.
+func SetupApplication(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.ApplicationKind)
 
-	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	cps := []managed.ConnectionPublisher{connection.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	log := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.Application{}).
+		Watches(&source.Kind{Type: &v1alpha1.Application{}}, jitter.NewEventHandler(o.Jitter.For(v1alpha1.ApplicationKind)), builder.WithPredicates(shard.NewPredicate(o.ShardSelector))).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.ApplicationGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: applications.NewApplicationServiceClient}),
+			managed.WithExternalConnecter(tracing.NewConnecter(v1alpha1.ApplicationKind, metrics.NewDriftConnecter(v1alpha1.ApplicationKind, &connector{kube: mgr.GetClient(), pool: o.Pool, lateInitialize: o.LateInitialize, newArgocdClientFn: applications.NewApplicationServiceClient, defaultAppNamespace: o.Namespace, log: log, recorder: recorder}))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient())),
-			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+			managed.WithLogger(log),
+			managed.WithRecorder(recorder),
 			managed.WithConnectionPublishers(cps...)))
 }
 
 type connector struct {
-	kube              client.Client
-	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) applications.ServiceClient
+	kube                client.Client
+	pool                *clients.Pool
+	newArgocdClientFn   func(c *clients.PooledClient) (applications.ServiceClient, error)
+	defaultAppNamespace string
+	log                 logging.Logger
+	recorder            event.Recorder
+	lateInitialize      bool
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -78,16 +121,24 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if !ok {
 		return nil, errors.New(errNotApplication)
 	}
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	pc, err := c.pool.Connect(ctx, c.kube, cr)
 	if err != nil {
 		return nil, err
 	}
-	return &external{kube: c.kube, client: c.newArgocdClientFn(cfg)}, nil
+	argocdClient, err := c.newArgocdClientFn(pc)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.kube, client: argocdClient, lateInitialize: c.lateInitialize, defaultAppNamespace: c.defaultAppNamespace, log: c.log, recorder: c.recorder}, nil
 }
 
 type external struct {
-	kube   client.Client
-	client applications.ServiceClient
+	kube                client.Client
+	client              applications.ServiceClient
+	defaultAppNamespace string
+	log                 logging.Logger
+	recorder            event.Recorder
+	lateInitialize      bool
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -102,52 +153,109 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, nil
 	}
 
-	appQuery := application.ApplicationQuery{
-		Name: &name,
-	}
-
-	// we have to use List() because Get() returns permission error
-	var apps *argocdv1alpha1.ApplicationList
-	apps, err := e.client.List(ctx, &appQuery)
+	app, err := e.getApplication(ctx, cr)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errListFailed)
+		return managed.ExternalObservation{}, err
 	}
-	app := &argocdv1alpha1.Application{}
-	for _, item := range apps.Items {
-		if item.Name == name && item.Spec.Project == cr.Spec.ForProvider.Project {
-			app = item.DeepCopy()
-		}
-	}
-	if app.Name == "" {
+	if app == nil {
 		return managed.ExternalObservation{}, nil
 	}
 
+	if owner := applicationSetOwner(app); owner != "" && cr.GetAnnotations()[v1alpha1.AnnotationKeyManageApplicationSetOwned] != "true" {
+		// The ApplicationSet controller reverts updates and recreates deleted
+		// applications, so back off. A deleted Application is released
+		// without deleting the application of the ApplicationSet.
+		cr.Status.AtProvider = generateApplicationObservation(app)
+		cr.Status.SetConditions(v1alpha1.OwnedByApplicationSet(fmt.Sprintf(errFmtOwnedByAppSet, owner, v1alpha1.AnnotationKeyManageApplicationSetOwned)))
+		return managed.ExternalObservation{
+			ResourceExists:    !meta.WasDeleted(cr),
+			ResourceUpToDate:  true,
+			ConnectionDetails: generateConnectionDetails(app),
+		}, nil
+	}
+
 	current := cr.Spec.ForProvider.DeepCopy()
-	lateInitialize(&cr.Spec.ForProvider, app)
+	// Without late initialization, unset fields are compared with the values
+	// ArgoCD defaulted, but these are never written to the spec.
+	desired := &cr.Spec.ForProvider
+	if !clients.ShouldLateInitialize(cr, e.lateInitialize) {
+		desired = desired.DeepCopy()
+	}
+	lateInitialize(desired, app)
 
+	upToDate := IsApplicationUpToDate(desired, app) && !hasRemovedLabels(app.Labels, desired.Labels, cr.Status.ManagedLabels)
+	cr.Status.ManagedLabels = managedLabels(app.Labels, desired.Labels, cr.Status.ManagedLabels)
 	cr.Status.AtProvider = generateApplicationObservation(app)
-	cr.Status.SetConditions(xpv1.Available())
+	// A rejected move is reported until the application is in its desired
+	// project, see Update.
+	if app.Spec.Project == cr.Spec.ForProvider.Project || cr.Status.GetCondition(xpv1.TypeReady).Reason != v1alpha1.ReasonProjectMismatch {
+		cr.Status.SetConditions(xpv1.Available())
+	}
+	setApplicationWarnings(cr)
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        IsApplicationUpToDate(&cr.Spec.ForProvider, app),
+		ResourceUpToDate:        upToDate && !isTerminationPending(cr),
 		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+		ConnectionDetails:       generateConnectionDetails(app),
 	}, nil
 }
 
+// getApplication returns the application with the external name of the
+// supplied resource, or nil if there is none.
+func (e *external) getApplication(ctx context.Context, cr *v1alpha1.Application) (*argocdv1alpha1.Application, error) {
+	name := meta.GetExternalName(cr)
+	appQuery := application.ApplicationQuery{
+		Name:         &name,
+		AppNamespace: e.appNamespace(cr),
+	}
+
+	// we have to use List() because Get() returns permission error
+	apps, err := e.client.List(ctx, &appQuery)
+	if err != nil {
+		return nil, errors.Wrap(clients.HandleMaintenance(cr, err), errListFailed)
+	}
+	for _, item := range apps.Items {
+		if item.Name == name && isInAppNamespace(&item, appQuery.AppNamespace) {
+			return item.DeepCopy(), nil
+		}
+	}
+	return nil, nil
+}
+
+// generateConnectionDetails returns the revision the application is synced
+// to, which is not the target revision of its source. Applications that were
+// never synced have none.
+func generateConnectionDetails(app *argocdv1alpha1.Application) managed.ConnectionDetails {
+	if app.Status.Sync.Revision == "" {
+		return nil
+	}
+	return managed.ConnectionDetails{
+		connectionKeySyncedRevision: []byte(app.Status.Sync.Revision),
+	}
+}
+
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) { // nolint:gocyclo
 	cr, ok := mg.(*v1alpha1.Application)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotApplication)
 	}
 
-	createRequest := generateCreateApplicationRequest(cr)
+	if err := validateSources(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	createRequest := generateCreateApplicationRequest(cr, e.appNamespace(cr))
 
 	_, err := e.client.Create(ctx, createRequest)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
 	}
 
+	if ptr.Deref(cr.Spec.ForProvider.SyncAfterCreate, false) {
+		e.syncAfterCreate(ctx, cr)
+	}
+
 	return managed.ExternalCreation{}, errors.Wrap(nil, errKubeUpdateFailed)
 }
 
@@ -156,9 +264,26 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotApplication)
 	}
-	updateRequest := generateUpdateRepositoryOptions(cr)
-	_, err := e.client.Update(ctx, updateRequest)
+	if err := validateSources(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	app, err := e.getApplication(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if app != nil && isTerminationPending(cr) {
+		if err := e.terminateOperation(ctx, cr, app); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	updateRequest := generateUpdateRepositoryOptions(cr, e.appNamespace(cr), app)
+	_, err = e.client.Update(ctx, updateRequest)
 	if err != nil {
+		// ArgoCD rejects moving an application to a project that does not
+		// exist or does not permit it, so report why it is not moved.
+		if app != nil && app.Spec.Project != cr.Spec.ForProvider.Project {
+			cr.Status.SetConditions(v1alpha1.ProjectMismatch(fmt.Sprintf(errFmtProjectMismatch, app.Spec.Project, cr.Spec.ForProvider.Project, err)))
+		}
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
 	}
 
@@ -171,7 +296,8 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotApplication)
 	}
 	query := application.ApplicationDeleteRequest{
-		Name: clients.StringToPtr(meta.GetExternalName(cr)),
+		Name:         clients.StringToPtr(meta.GetExternalName(cr)),
+		AppNamespace: e.appNamespace(cr),
 	}
 
 	_, err := e.client.Delete(ctx, &query)
@@ -179,6 +305,172 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	return errors.Wrap(err, errDeleteFailed)
 }
 
+// syncAfterCreate starts a sync of the supplied application, which was just
+// created, and records the result. It does not wait for the sync to complete.
+// A failed sync does not fail Create, since the application exists, and is
+// only logged and reported as a warning event. ArgoCD retries the sync with
+// the retry strategy of the sync policy, even if it is not automated.
+func (e *external) syncAfterCreate(ctx context.Context, cr *v1alpha1.Application) {
+	req := &application.ApplicationSyncRequest{
+		Name:         clients.StringToPtr(meta.GetExternalName(cr)),
+		AppNamespace: e.appNamespace(cr),
+	}
+	converter := v1alpha1.ConverterImpl{}
+	if spec := converter.ToArgoApplicationSpec(&cr.Spec.ForProvider); spec.SyncPolicy != nil {
+		req.RetryStrategy = spec.SyncPolicy.Retry
+	}
+	if _, err := e.client.Sync(ctx, req); err != nil {
+		err = errors.Wrap(err, errSyncAfterCreate)
+		e.log.Info(err.Error(), "name", cr.GetName())
+		e.recorder.Event(cr, event.Warning(reasonSyncAfterCreate, err))
+		return
+	}
+	e.recorder.Event(cr, event.Normal(reasonSyncAfterCreate, "Started sync of created application"))
+}
+
+// isTerminationPending returns true if the terminate-operation annotation of
+// the supplied application holds a value that was not handled yet. The
+// operation is terminated by the next Update.
+func isTerminationPending(cr *v1alpha1.Application) bool {
+	trigger := cr.GetAnnotations()[v1alpha1.AnnotationKeyTerminateOperation]
+	return trigger != "" && (cr.Status.OperationTermination == nil || cr.Status.OperationTermination.Trigger != trigger)
+}
+
+// terminateOperation terminates the running operation of the supplied
+// application, and records that the value of its terminate-operation
+// annotation was handled. Nothing is terminated if no operation is running.
+func (e *external) terminateOperation(ctx context.Context, cr *v1alpha1.Application, app *argocdv1alpha1.Application) error {
+	result := v1alpha1.TerminationResultNoOperation
+	if app.Operation != nil {
+		req := &application.OperationTerminateRequest{
+			Name:         clients.StringToPtr(meta.GetExternalName(cr)),
+			AppNamespace: e.appNamespace(cr),
+			Project:      clients.StringToPtr(app.Spec.Project),
+		}
+		if _, err := e.client.TerminateOperation(ctx, req); err != nil {
+			return errors.Wrap(err, errTerminateOperation)
+		}
+		result = v1alpha1.TerminationResultTerminated
+	}
+	cr.Status.OperationTermination = &v1alpha1.OperationTermination{
+		Trigger:   cr.GetAnnotations()[v1alpha1.AnnotationKeyTerminateOperation],
+		Result:    result,
+		HandledAt: metav1.Now(),
+	}
+	return nil
+}
+
+// validateSources rejects sources that set both a Helm chart and a Git path,
+// which ArgoCD cannot resolve to a single source type.
+func validateSources(p *v1alpha1.ApplicationParameters) error {
+	sources := p.Sources
+	if p.Source != nil {
+		sources = append(v1alpha1.ApplicationSources{*p.Source}, sources...)
+	}
+	for _, src := range sources {
+		if src.Chart != nil && src.Path != nil {
+			return errors.New(errChartAndPath)
+		}
+		if err := validateDirectoryGlobs(src.Directory); err != nil {
+			return err
+		}
+		// ArgoCD sends an empty name to the plugin discovery as well, which
+		// would hide a name that was templated to an empty string by mistake.
+		if src.Plugin != nil && src.Plugin.Name != nil && *src.Plugin.Name == "" {
+			return errors.New(errEmptyPluginName)
+		}
+	}
+	return nil
+}
+
+// setApplicationWarnings reports non-fatal findings about the desired
+// Application as a Warning condition. ArgoCD accepts such applications, so
+// the resource stays available.
+func setApplicationWarnings(cr *v1alpha1.Application) {
+	warnings := generateInfoWarnings(cr.Spec.ForProvider.Info)
+	if skipValidation(cr) {
+		warnings = append([]string{fmt.Sprintf(warnFmtSkipValidation, v1alpha1.AnnotationKeySkipValidation)}, warnings...)
+	}
+	if len(warnings) > 0 {
+		cr.Status.SetConditions(apisv1alpha1.ValidationWarning(strings.Join(warnings, "; ")))
+		return
+	}
+	if cr.Status.GetCondition(apisv1alpha1.TypeWarning).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(apisv1alpha1.NoWarnings())
+	}
+}
+
+// skipValidation returns true if the supplied application is created and
+// updated without validation by ArgoCD.
+func skipValidation(cr *v1alpha1.Application) bool {
+	return cr.GetAnnotations()[v1alpha1.AnnotationKeySkipValidation] == "true"
+}
+
+// generateInfoWarnings reports info items that ArgoCD renders as links, but
+// whose value is no valid URL. Other values are free-form.
+func generateInfoWarnings(info []v1alpha1.Info) []string {
+	var warnings []string
+	for _, i := range info {
+		if !strings.HasPrefix(i.Value, "http://") && !strings.HasPrefix(i.Value, "https://") {
+			continue
+		}
+		if u, err := url.Parse(i.Value); err != nil || u.Host == "" {
+			warnings = append(warnings, fmt.Sprintf(warnFmtInfoURL, i.Name, i.Value))
+		}
+	}
+	return warnings
+}
+
+// validateDirectoryGlobs returns an error if the include or exclude pattern of
+// a directory source is no valid glob. ArgoCD treats such a pattern as
+// matching nothing, so a typo would silently deploy no or all manifests.
+func validateDirectoryGlobs(d *v1alpha1.ApplicationSourceDirectory) error {
+	if d == nil {
+		return nil
+	}
+	if err := validateGlob("include", d.Include); err != nil {
+		return err
+	}
+	return validateGlob("exclude", d.Exclude)
+}
+
+func validateGlob(field string, pattern *string) error {
+	if pattern == nil {
+		return nil
+	}
+	_, err := glob.Compile(*pattern)
+	return errors.Wrapf(err, errFmtDirectoryGlob, field, *pattern)
+}
+
+// appNamespace returns the app namespace of the given application, falling
+// back to the configured default.
+func (e *external) appNamespace(cr *v1alpha1.Application) *string {
+	if cr.Spec.ForProvider.AppNamespace != nil {
+		return cr.Spec.ForProvider.AppNamespace
+	}
+	return clients.StringToPtr(e.defaultAppNamespace)
+}
+
+// isInAppNamespace reports whether the given application lives in the
+// requested app namespace. ArgoCD lists the applications of all namespaces if
+// none is requested, so an unset namespace is no wildcard. The provider
+// requests the namespace ArgoCD is installed in by default, see appNamespace.
+func isInAppNamespace(app *argocdv1alpha1.Application, appNamespace *string) bool {
+	return app.Namespace == clients.StringValue(appNamespace)
+}
+
+// applicationSetOwner returns the name of the ApplicationSet owning the given
+// application, or an empty string if there is none.
+func applicationSetOwner(app *argocdv1alpha1.Application) string {
+	for _, o := range app.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(o.APIVersion)
+		if err == nil && gv.Group == argocdv1alpha1.SchemeGroupVersion.Group && o.Kind == kindApplicationSet {
+			return o.Name
+		}
+	}
+	return ""
+}
+
 func lateInitialize(applicationParameters *v1alpha1.ApplicationParameters, app *argocdv1alpha1.Application) { // nolint:gocyclo
 	if app == nil {
 		return
@@ -199,14 +491,16 @@ func generateApplicationObservation(app *argocdv1alpha1.Application) v1alpha1.Ar
 	return *status
 }
 
-func generateCreateApplicationRequest(cr *v1alpha1.Application) *application.ApplicationCreateRequest {
+func generateCreateApplicationRequest(cr *v1alpha1.Application, appNamespace *string) *application.ApplicationCreateRequest {
 	converter := v1alpha1.ConverterImpl{}
 	spec := converter.ToArgoApplicationSpec(&cr.Spec.ForProvider)
 
 	app := &argocdv1alpha1.Application{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: meta.GetExternalName(cr),
+			Name:      meta.GetExternalName(cr),
+			Namespace: clients.StringValue(appNamespace),
+			Labels:    withoutArgoCDLabels(cr.Spec.ForProvider.Labels),
 		},
 		Spec: *spec,
 	}
@@ -214,19 +508,33 @@ func generateCreateApplicationRequest(cr *v1alpha1.Application) *application.App
 	repoCreateRequest := &application.ApplicationCreateRequest{
 		Application: app,
 	}
+	if skipValidation(cr) {
+		repoCreateRequest.Validate = ptr.To(false)
+	}
 
 	return repoCreateRequest
 }
 
-func generateUpdateRepositoryOptions(cr *v1alpha1.Application) *application.ApplicationUpdateRequest {
+// generateUpdateRepositoryOptions returns the update of the supplied current
+// application, which may be nil if it was not observed. The update replaces
+// all labels, so the current labels are kept except for the managed ones that
+// are no longer desired, and the desired ones are set on top.
+func generateUpdateRepositoryOptions(cr *v1alpha1.Application, appNamespace *string, current *argocdv1alpha1.Application) *application.ApplicationUpdateRequest {
 	converter := v1alpha1.ConverterImpl{}
 
 	spec := converter.ToArgoApplicationSpec(&cr.Spec.ForProvider)
 
+	var labels map[string]string
+	if current != nil {
+		labels = current.Labels
+	}
+
 	app := &argocdv1alpha1.Application{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: meta.GetExternalName(cr),
+			Name:      meta.GetExternalName(cr),
+			Namespace: clients.StringValue(appNamespace),
+			Labels:    mergeLabels(labels, cr.Spec.ForProvider.Labels, cr.Status.ManagedLabels),
 		},
 		Spec: *spec,
 	}
@@ -234,5 +542,8 @@ func generateUpdateRepositoryOptions(cr *v1alpha1.Application) *application.Appl
 	o := &application.ApplicationUpdateRequest{
 		Application: app,
 	}
+	if skipValidation(cr) {
+		o.Validate = ptr.To(false)
+	}
 	return o
 }
@@ -18,21 +18,31 @@ package applications
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	argocdApplication "github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/gobwas/glob"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/applications"
 	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/applications"
 )
@@ -47,11 +57,32 @@ var (
 	chartPath                   = "charts/podinfo"
 	revision                    = "HEAD"
 	selfHealEnabled             = true
+	testAppNamespace            = "team-a"
+	zeroRevisionHistoryLimit    int64
+	helmRepoURL                 = "https://stefanprodan.github.io/podinfo"
+	chartName                   = "podinfo"
+	chartVersion                = "6.5.0"
+	testLabels                  = map[string]string{"team": "a"}
+	testArgoCDLabel             = "argocd.argoproj.io/instance"
+	testBranch                  = "main"
+	testValuesRef               = "values"
+	testOverlayPath             = "kustomize"
+	testBasePath                = "base"
+	ignoreMissingValueFiles     = true
+	helmFlagEnabled             = true
+	helmFlagDisabled            = false
+	testMissingProjectName      = "missing"
+	testOtherProjectName        = "team-b"
+	testMalformedGlob           = "manifests/[z-a]*.yaml"
+	testKustomizePrefix         = "dev-"
+	testKustomizeNamespace      = "team-a"
+	errProjectNotFound          = errors.New(`appproject.argoproj.io "missing" not found`)
 )
 
 type args struct {
-	client applications.ServiceClient
-	cr     *v1alpha1.Application
+	client              applications.ServiceClient
+	cr                  *v1alpha1.Application
+	defaultAppNamespace string
 }
 
 type mockModifier func(*mockclient.MockServiceClient)
@@ -93,6 +124,18 @@ func withObservation(p v1alpha1.ArgoApplicationStatus) ApplicationModifier {
 	return func(r *v1alpha1.Application) { r.Status.AtProvider = p }
 }
 
+func withAnnotations(a map[string]string) ApplicationModifier {
+	return func(r *v1alpha1.Application) { meta.AddAnnotations(r, a) }
+}
+
+func withOperationTermination(o *v1alpha1.OperationTermination) ApplicationModifier {
+	return func(r *v1alpha1.Application) { r.Status.OperationTermination = o }
+}
+
+func withManagedLabels(k ...string) ApplicationModifier {
+	return func(r *v1alpha1.Application) { r.Status.ManagedLabels = k }
+}
+
 func withConditions(c ...xpv1.Condition) ApplicationModifier {
 	return func(r *v1alpha1.Application) { r.Status.ConditionedStatus.Conditions = c }
 }
@@ -108,6 +151,154 @@ func TestObserve(t *testing.T) {
 		args
 		want
 	}{
+		"ProjectChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testMissingProjectName,
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testMissingProjectName,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+			},
+		},
+		"ProjectMismatchKept": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testMissingProjectName,
+					}),
+					withConditions(v1alpha1.ProjectMismatch(`application is in project "default" instead of "missing": appproject.argoproj.io "missing" not found`)),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testMissingProjectName,
+					}),
+					withConditions(v1alpha1.ProjectMismatch(`application is in project "default" instead of "missing": appproject.argoproj.io "missing" not found`)),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+			},
+		},
+		"MalformedInfoURL": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Info: []argocdv1alpha1.Info{
+										{Name: "Docs", Value: "https://docs.example.org/podinfo"},
+										{Name: "Dashboard", Value: "https://grafana example.org"},
+										{Name: "Owner", Value: "team-a"},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Info: []v1alpha1.Info{
+							{Name: "Docs", Value: "https://docs.example.org/podinfo"},
+							{Name: "Dashboard", Value: "https://grafana example.org"},
+							{Name: "Owner", Value: "team-a"},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Info: []v1alpha1.Info{
+							{Name: "Docs", Value: "https://docs.example.org/podinfo"},
+							{Name: "Dashboard", Value: "https://grafana example.org"},
+							{Name: "Owner", Value: "team-a"},
+						},
+					}),
+					withConditions(
+						xpv1.Available(),
+						apisv1alpha1.ValidationWarning(`info "Dashboard" is rendered as a link, but "https://grafana example.org" is no valid URL`),
+					),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+			},
+		},
 		"SuccessfulAvailable": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
@@ -277,29 +468,98 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
-		"ListApplicationFailed": {
+		"SameNameInDifferentAppNamespaces": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
 					mcs.EXPECT().List(
 						context.Background(),
 						&argocdApplication.ApplicationQuery{
-							Name: &testApplicationExternalName,
+							Name:         &testApplicationExternalName,
+							AppNamespace: &testAppNamespace,
 						},
 					).Return(
-						nil, errBoom)
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:      testApplicationExternalName,
+										Namespace: testAppNamespace,
+									},
+									Spec: argocdv1alpha1.ApplicationSpec{
+										Project: testProjectName,
+										Source: &argocdv1alpha1.ApplicationSource{
+											RepoURL:        repoURL,
+											Path:           chartPath,
+											TargetRevision: revision,
+										},
+										Destination: argocdv1alpha1.ApplicationDestination{
+											Namespace: testDestinationNamespace,
+										},
+									},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:      testApplicationExternalName,
+										Namespace: "argocd",
+									},
+									Spec: argocdv1alpha1.ApplicationSpec{
+										Project: testProjectName,
+										Source: &argocdv1alpha1.ApplicationSource{
+											RepoURL:        repoURL,
+											Path:           "other",
+											TargetRevision: revision,
+										},
+										Destination: argocdv1alpha1.ApplicationDestination{
+											Namespace: testDestinationNamespace,
+										},
+									},
+								},
+							},
+						}, nil)
 				}),
 				cr: Application(
 					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:      testProjectName,
+						AppNamespace: &testAppNamespace,
+						Destination: v1alpha1.ApplicationDestination{
+							Namespace: &testDestinationNamespace,
+						},
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+						},
+					}),
 				),
 			},
 			want: want{
 				cr: Application(
 					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:      testProjectName,
+						AppNamespace: &testAppNamespace,
+						Destination: v1alpha1.ApplicationDestination{
+							Namespace: &testDestinationNamespace,
+						},
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
 				),
-				err: errors.Wrap(errBoom, errListFailed),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
 			},
 		},
-		"NeedsCreation": {
+		"ZeroRevisionHistoryLimitUpToDate": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
 					mcs.EXPECT().List(
@@ -309,14 +569,65 @@ func TestObserve(t *testing.T) {
 						},
 					).Return(
 						&argocdv1alpha1.ApplicationList{
-							TypeMeta: metav1.TypeMeta{},
-							ListMeta: metav1.ListMeta{},
-							Items:    []argocdv1alpha1.Application{},
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project:              testProjectName,
+									RevisionHistoryLimit: &zeroRevisionHistoryLimit,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:              testProjectName,
+						RevisionHistoryLimit: &zeroRevisionHistoryLimit,
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project:              testProjectName,
+						RevisionHistoryLimit: &zeroRevisionHistoryLimit,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"DefaultAppNamespace": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name:         &testApplicationExternalName,
+							AppNamespace: &testAppNamespace,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      testApplicationExternalName,
+									Namespace: "argocd",
+								},
+							}},
 						}, nil)
 				}),
 				cr: Application(
 					withExternalName(testApplicationExternalName),
 				),
+				defaultAppNamespace: testAppNamespace,
 			},
 			want: want{
 				cr: Application(
@@ -328,16 +639,31 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
-		"NoExternalName -> NeedsCreation": {
+		"UnsetAppNamespaceIsNoWildcard": {
 			args: args{
-				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      testApplicationExternalName,
+									Namespace: testAppNamespace,
+								},
+							}},
+						}, nil)
+				}),
 				cr: Application(
-					withName(testApplicationExternalName),
+					withExternalName(testApplicationExternalName),
 				),
 			},
 			want: want{
 				cr: Application(
-					withName(testApplicationExternalName),
+					withExternalName(testApplicationExternalName),
 				),
 				result: managed.ExternalObservation{
 					ResourceExists: false,
@@ -345,58 +671,2444 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
-			o, err := e.Observe(context.Background(), tc.args.cr)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.result, o); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
+		"LabelsUpToDateIgnoringArgoCDLabels": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"team": "a", "argocd.argoproj.io/instance": "parent"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+					withManagedLabels("team"),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"LabelAdded": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"argocd.argoproj.io/instance": "parent"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+					withManagedLabels("team"),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"LabelChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"team": "b"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+					withManagedLabels("team"),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"UnmanagedLabelIgnored": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"team": "a", "owner": "b"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+					withManagedLabels("team"),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"LabelRemoved": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"team": "a", "owner": "b"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+					withManagedLabels("owner", "team"),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+					withManagedLabels("owner", "team"),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"RemovedLabelForgotten": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"team": "a"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+					withManagedLabels("owner", "team"),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  map[string]string{"team": "a"},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+					withManagedLabels("team"),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"UnsetLabelsIgnored": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"team": "a", "owner": "b"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"EmptyTargetRevisionEqualsHEAD": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										TargetRevision: revision,
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"TargetRevisionBranchChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										TargetRevision: "develop",
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							TargetRevision: &testBranch,
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							TargetRevision: &testBranch,
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"SourcesTargetRevisionsUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Sources: argocdv1alpha1.ApplicationSources{
+										{
+											RepoURL:        repoURL,
+											Path:           testBasePath,
+											TargetRevision: revision,
+										},
+										{
+											RepoURL:        repoURL,
+											Path:           testOverlayPath,
+											TargetRevision: testBranch,
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL: repoURL,
+								Path:    &testBasePath,
+							},
+							{
+								RepoURL:        repoURL,
+								Path:           &testOverlayPath,
+								TargetRevision: &testBranch,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL: repoURL,
+								Path:    &testBasePath,
+							},
+							{
+								RepoURL:        repoURL,
+								Path:           &testOverlayPath,
+								TargetRevision: &testBranch,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"SourcesTargetRevisionBranchChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Sources: argocdv1alpha1.ApplicationSources{
+										{
+											RepoURL:        repoURL,
+											Path:           testBasePath,
+											TargetRevision: revision,
+										},
+										{
+											RepoURL:        repoURL,
+											Path:           testOverlayPath,
+											TargetRevision: "develop",
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL: repoURL,
+								Path:    &testBasePath,
+							},
+							{
+								RepoURL:        repoURL,
+								Path:           &testOverlayPath,
+								TargetRevision: &testBranch,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL: repoURL,
+								Path:    &testBasePath,
+							},
+							{
+								RepoURL:        repoURL,
+								Path:           &testOverlayPath,
+								TargetRevision: &testBranch,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"IgnoreMissingValueFilesUnsetUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										Path:           chartPath,
+										TargetRevision: revision,
+										Helm: &argocdv1alpha1.ApplicationSourceHelm{
+											ValueFiles:              []string{"values-prod.yaml"},
+											IgnoreMissingValueFiles: false,
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								ValueFiles: []string{"values-prod.yaml"},
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								ValueFiles: []string{"values-prod.yaml"},
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"IgnoreMissingValueFilesChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										Path:           chartPath,
+										TargetRevision: revision,
+										Helm: &argocdv1alpha1.ApplicationSourceHelm{
+											ValueFiles:              []string{"values-prod.yaml"},
+											IgnoreMissingValueFiles: false,
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								ValueFiles:              []string{"values-prod.yaml"},
+								IgnoreMissingValueFiles: &ignoreMissingValueFiles,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								ValueFiles:              []string{"values-prod.yaml"},
+								IgnoreMissingValueFiles: &ignoreMissingValueFiles,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"KustomizeNamespaceUnsetUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										Path:           chartPath,
+										TargetRevision: revision,
+										Kustomize:      &argocdv1alpha1.ApplicationSourceKustomize{NamePrefix: "dev-"},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Kustomize:      &v1alpha1.ApplicationSourceKustomize{NamePrefix: &testKustomizePrefix},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Kustomize:      &v1alpha1.ApplicationSourceKustomize{NamePrefix: &testKustomizePrefix},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"KustomizeNamespaceUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										Path:           chartPath,
+										TargetRevision: revision,
+										Kustomize:      &argocdv1alpha1.ApplicationSourceKustomize{NamePrefix: "dev-", Namespace: "team-a"},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Kustomize:      &v1alpha1.ApplicationSourceKustomize{NamePrefix: &testKustomizePrefix, Namespace: &testKustomizeNamespace},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Kustomize:      &v1alpha1.ApplicationSourceKustomize{NamePrefix: &testKustomizePrefix, Namespace: &testKustomizeNamespace},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"KustomizeNamespaceChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										Path:           chartPath,
+										TargetRevision: revision,
+										Kustomize:      &argocdv1alpha1.ApplicationSourceKustomize{NamePrefix: "dev-", Namespace: "team-b"},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Kustomize:      &v1alpha1.ApplicationSourceKustomize{NamePrefix: &testKustomizePrefix, Namespace: &testKustomizeNamespace},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Kustomize:      &v1alpha1.ApplicationSourceKustomize{NamePrefix: &testKustomizePrefix, Namespace: &testKustomizeNamespace},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"HelmFlagsUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										Path:           chartPath,
+										TargetRevision: revision,
+										Helm: &argocdv1alpha1.ApplicationSourceHelm{
+											SkipCrds:        true,
+											PassCredentials: true,
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								SkipCrds:        &helmFlagEnabled,
+								PassCredentials: &helmFlagEnabled,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								SkipCrds:        &helmFlagEnabled,
+								PassCredentials: &helmFlagEnabled,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"SkipCrdsChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										Path:           chartPath,
+										TargetRevision: revision,
+										Helm: &argocdv1alpha1.ApplicationSourceHelm{
+											SkipCrds:        true,
+											PassCredentials: true,
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								SkipCrds:        &helmFlagDisabled,
+								PassCredentials: &helmFlagEnabled,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								SkipCrds:        &helmFlagDisabled,
+								PassCredentials: &helmFlagEnabled,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"PassCredentialsChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        repoURL,
+										Path:           chartPath,
+										TargetRevision: revision,
+										Helm: &argocdv1alpha1.ApplicationSourceHelm{
+											SkipCrds:        false,
+											PassCredentials: false,
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								SkipCrds:        &helmFlagDisabled,
+								PassCredentials: &helmFlagEnabled,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        repoURL,
+							Path:           &chartPath,
+							TargetRevision: &revision,
+							Helm: &v1alpha1.ApplicationSourceHelm{
+								SkipCrds:        &helmFlagDisabled,
+								PassCredentials: &helmFlagEnabled,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"SourcesRefReordered": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Sources: argocdv1alpha1.ApplicationSources{
+										{
+											RepoURL:        repoURL,
+											TargetRevision: revision,
+											Ref:            testValuesRef,
+										},
+										{
+											RepoURL:        helmRepoURL,
+											Chart:          chartName,
+											TargetRevision: chartVersion,
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL:        helmRepoURL,
+								Chart:          &chartName,
+								TargetRevision: &chartVersion,
+							},
+							{
+								RepoURL:        repoURL,
+								TargetRevision: &revision,
+								Ref:            &testValuesRef,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL:        helmRepoURL,
+								Chart:          &chartName,
+								TargetRevision: &chartVersion,
+							},
+							{
+								RepoURL:        repoURL,
+								TargetRevision: &revision,
+								Ref:            &testValuesRef,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"SourcesReordered": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							Items: []argocdv1alpha1.Application{{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Sources: argocdv1alpha1.ApplicationSources{
+										{
+											RepoURL:        repoURL,
+											Path:           testOverlayPath,
+											TargetRevision: revision,
+										},
+										{
+											RepoURL:        helmRepoURL,
+											Chart:          chartName,
+											TargetRevision: chartVersion,
+										},
+									},
+								},
+							}},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL:        helmRepoURL,
+								Chart:          &chartName,
+								TargetRevision: &chartVersion,
+							},
+							{
+								RepoURL:        repoURL,
+								Path:           &testOverlayPath,
+								TargetRevision: &revision,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL:        helmRepoURL,
+								Chart:          &chartName,
+								TargetRevision: &chartVersion,
+							},
+							{
+								RepoURL:        repoURL,
+								Path:           &testOverlayPath,
+								TargetRevision: &revision,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(initializedArgoAppStatus()),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"ListApplicationFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						nil, errBoom)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+				),
+				err: errors.Wrap(errBoom, errListFailed),
+			},
+		},
+		"NeedsCreation": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.ApplicationList{
+							TypeMeta: metav1.TypeMeta{},
+							ListMeta: metav1.ListMeta{},
+							Items:    []argocdv1alpha1.Application{},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists: false,
+				},
+				err: nil,
+			},
+		},
+		"NoExternalName -> NeedsCreation": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Application(
+					withName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withName(testApplicationExternalName),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists: false,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, defaultAppNamespace: tc.defaultAppNamespace, lateInitialize: true}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func initializedArgoAppStatus() v1alpha1.ArgoApplicationStatus {
+	return v1alpha1.ArgoApplicationStatus{
+		Resources: nil,
+		Sync: v1alpha1.SyncStatus{
+			Revision: &emptyString,
+			ComparedTo: v1alpha1.ComparedTo{
+				Source: v1alpha1.ApplicationSource{
+					Path:           &emptyString,
+					TargetRevision: &emptyString,
+					Chart:          &emptyString,
+					Ref:            &emptyString,
+				},
+				Destination: v1alpha1.ApplicationDestination{
+					Server:    &emptyString,
+					Namespace: &emptyString,
+					Name:      &emptyString,
+				},
+			},
+		},
+		Health: v1alpha1.HealthStatus{
+			Message: &emptyString,
+		},
+		SourceType:           "",
+		Summary:              v1alpha1.ApplicationSummary{},
+		ResourceHealthSource: "",
+	}
+}
+
+func TestTerminateOperation(t *testing.T) {
+	running := &argocdv1alpha1.Operation{Sync: &argocdv1alpha1.SyncOperation{}}
+
+	cases := map[string]struct {
+		operation  *argocdv1alpha1.Operation
+		cr         *v1alpha1.Application
+		terminates int
+		want       *v1alpha1.OperationTermination
+	}{
+		"OperationRunning": {
+			operation: running,
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+				withAnnotations(map[string]string{v1alpha1.AnnotationKeyTerminateOperation: "1"}),
+			),
+			terminates: 1,
+			want:       &v1alpha1.OperationTermination{Trigger: "1", Result: v1alpha1.TerminationResultTerminated},
+		},
+		"NoOperationRunning": {
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+				withAnnotations(map[string]string{v1alpha1.AnnotationKeyTerminateOperation: "1"}),
+			),
+			want: &v1alpha1.OperationTermination{Trigger: "1", Result: v1alpha1.TerminationResultNoOperation},
+		},
+		"TriggerAlreadyHandled": {
+			operation: running,
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+				withAnnotations(map[string]string{v1alpha1.AnnotationKeyTerminateOperation: "1"}),
+				withOperationTermination(&v1alpha1.OperationTermination{Trigger: "1", Result: v1alpha1.TerminationResultNoOperation}),
+			),
+			want: &v1alpha1.OperationTermination{Trigger: "1", Result: v1alpha1.TerminationResultNoOperation},
+		},
+		"NewTrigger": {
+			operation: running,
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+				withAnnotations(map[string]string{v1alpha1.AnnotationKeyTerminateOperation: "2"}),
+				withOperationTermination(&v1alpha1.OperationTermination{Trigger: "1", Result: v1alpha1.TerminationResultTerminated}),
+			),
+			terminates: 1,
+			want:       &v1alpha1.OperationTermination{Trigger: "2", Result: v1alpha1.TerminationResultTerminated},
+		},
+		"NoTrigger": {
+			operation: running,
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+			),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{{
+						ObjectMeta: metav1.ObjectMeta{Name: testApplicationExternalName},
+						Spec:       argocdv1alpha1.ApplicationSpec{Project: testProjectName},
+						Operation:  tc.operation,
+					}},
+				}, nil).AnyTimes()
+				mcs.EXPECT().TerminateOperation(
+					context.Background(),
+					&argocdApplication.OperationTerminateRequest{
+						Name:    &testApplicationExternalName,
+						Project: &testProjectName,
+					},
+				).Return(&argocdApplication.OperationTerminateResponse{}, nil).Times(tc.terminates)
+				mcs.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.Application{}, nil).AnyTimes()
+			})
+			e := &external{client: client}
+
+			// Observing must not terminate the operation, but Update does.
+			// Reconciling again must not terminate it again.
+			for i := 0; i < 2; i++ {
+				o, err := e.Observe(context.Background(), tc.cr)
+				if err != nil {
+					t.Fatalf("Observe(...): %v", err)
+				}
+				if o.ResourceUpToDate {
+					continue
+				}
+				if _, err := e.Update(context.Background(), tc.cr); err != nil {
+					t.Fatalf("Update(...): %v", err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, tc.cr.Status.OperationTermination, cmpopts.IgnoreFields(v1alpha1.OperationTermination{}, "HandledAt")); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateInfoWarnings(t *testing.T) {
+	cases := map[string]struct {
+		info []v1alpha1.Info
+		want []string
+	}{
+		"ValidURL": {
+			info: []v1alpha1.Info{{Name: "Docs", Value: "https://docs.example.org/podinfo"}},
+		},
+		"FreeForm": {
+			info: []v1alpha1.Info{{Name: "Owner", Value: "team-a, see http docs"}},
+		},
+		"MalformedURL": {
+			info: []v1alpha1.Info{{Name: "Dashboard", Value: "https://grafana example.org"}},
+			want: []string{`info "Dashboard" is rendered as a link, but "https://grafana example.org" is no valid URL`},
+		},
+		"MissingHost": {
+			info: []v1alpha1.Info{{Name: "Docs", Value: "http://"}},
+			want: []string{`info "Docs" is rendered as a link, but "http://" is no valid URL`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := generateInfoWarnings(tc.info)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("generateInfoWarnings(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveResourceHealth(t *testing.T) {
+	client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+		mcs.EXPECT().List(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.ApplicationList{
+			Items: []argocdv1alpha1.Application{{
+				ObjectMeta: metav1.ObjectMeta{Name: testApplicationExternalName},
+				Spec:       argocdv1alpha1.ApplicationSpec{Project: testProjectName},
+				Status: argocdv1alpha1.ApplicationStatus{
+					Health: argocdv1alpha1.HealthStatus{Status: health.HealthStatusHealthy},
+					Resources: []argocdv1alpha1.ResourceStatus{
+						{Kind: "Deployment", Name: "podinfo", Health: &argocdv1alpha1.HealthStatus{Status: health.HealthStatusHealthy}},
+						// Custom resources without a health check in ArgoCD.
+						{Group: "example.org", Kind: "Widget", Name: "podinfo", Health: &argocdv1alpha1.HealthStatus{Status: health.HealthStatusUnknown}},
+						{Group: "example.org", Kind: "Gadget", Name: "podinfo", Health: &argocdv1alpha1.HealthStatus{Status: health.HealthStatusMissing}},
+					},
+				},
+			}},
+		}, nil)
+	})
+	cr := Application(
+		withExternalName(testApplicationExternalName),
+		withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+	)
+
+	e := &external{client: client}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("Observe(...): %v", err)
+	}
+
+	// An Unknown or Missing resource health neither degrades the application
+	// nor makes it unavailable.
+	if diff := cmp.Diff(xpv1.Available(), cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("Observe(...) Ready: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(health.HealthStatusHealthy, cr.Status.AtProvider.Health.Status); diff != "" {
+		t.Errorf("Observe(...) health: -want, +got:\n%s", diff)
+	}
+	got := make([]health.HealthStatusCode, 0, len(cr.Status.AtProvider.Resources))
+	for _, r := range cr.Status.AtProvider.Resources {
+		got = append(got, r.Health.Status)
+	}
+	want := []health.HealthStatusCode{health.HealthStatusHealthy, health.HealthStatusUnknown, health.HealthStatusMissing}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Observe(...) resource health: -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsApplicationUpToDateSyncPolicyAndIgnoreDifferences(t *testing.T) {
+	ignoreReplicas := []v1alpha1.ResourceIgnoreDifferences{{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}}}
+	remote := func(automated *argocdv1alpha1.SyncPolicyAutomated, ignore []argocdv1alpha1.ResourceIgnoreDifferences) *argocdv1alpha1.Application {
+		return &argocdv1alpha1.Application{Spec: argocdv1alpha1.ApplicationSpec{
+			Project:           testProjectName,
+			SyncPolicy:        &argocdv1alpha1.SyncPolicy{Automated: automated},
+			IgnoreDifferences: ignore,
+		}}
+	}
+	ignoreReplicasRemote := []argocdv1alpha1.ResourceIgnoreDifferences{{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}}}
+
+	cases := map[string]struct {
+		automated *v1alpha1.SyncPolicyAutomated
+		ignore    []v1alpha1.ResourceIgnoreDifferences
+		remote    *argocdv1alpha1.Application
+		want      bool
+	}{
+		"UpToDate": {
+			automated: &v1alpha1.SyncPolicyAutomated{Prune: ptr.To(true), SelfHeal: &selfHealEnabled},
+			ignore:    ignoreReplicas,
+			remote:    remote(&argocdv1alpha1.SyncPolicyAutomated{Prune: true, SelfHeal: true}, ignoreReplicasRemote),
+			want:      true,
+		},
+		"PruneDisabled": {
+			automated: &v1alpha1.SyncPolicyAutomated{Prune: ptr.To(true), SelfHeal: &selfHealEnabled},
+			ignore:    ignoreReplicas,
+			remote:    remote(&argocdv1alpha1.SyncPolicyAutomated{SelfHeal: true}, ignoreReplicasRemote),
+			want:      false,
+		},
+		"AutomatedSyncDisabled": {
+			automated: &v1alpha1.SyncPolicyAutomated{Prune: ptr.To(true), SelfHeal: &selfHealEnabled},
+			ignore:    ignoreReplicas,
+			remote:    remote(nil, ignoreReplicasRemote),
+			want:      false,
+		},
+		"AutomatedSyncEnabled": {
+			ignore: ignoreReplicas,
+			remote: remote(&argocdv1alpha1.SyncPolicyAutomated{}, ignoreReplicasRemote),
+			want:   false,
+		},
+		"IgnoreDifferencesChanged": {
+			automated: &v1alpha1.SyncPolicyAutomated{Prune: ptr.To(true), SelfHeal: &selfHealEnabled},
+			ignore:    ignoreReplicas,
+			remote: remote(&argocdv1alpha1.SyncPolicyAutomated{Prune: true, SelfHeal: true}, []argocdv1alpha1.ResourceIgnoreDifferences{
+				{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/template/spec/containers/0/image"}},
+			}),
+			want: false,
+		},
+		"IgnoreDifferencesRemoved": {
+			automated: &v1alpha1.SyncPolicyAutomated{Prune: ptr.To(true), SelfHeal: &selfHealEnabled},
+			remote:    remote(&argocdv1alpha1.SyncPolicyAutomated{Prune: true, SelfHeal: true}, ignoreReplicasRemote),
+			want:      false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ApplicationParameters{
+				Project:           testProjectName,
+				SyncPolicy:        &v1alpha1.SyncPolicy{Automated: tc.automated},
+				IgnoreDifferences: tc.ignore,
+			}
+			if got := IsApplicationUpToDate(p, tc.remote); got != tc.want {
+				t.Errorf("IsApplicationUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRespectIgnoreDifferences(t *testing.T) {
+	p := &v1alpha1.ApplicationParameters{
+		Project: testProjectName,
+		SyncPolicy: &v1alpha1.SyncPolicy{
+			Automated:   &v1alpha1.SyncPolicyAutomated{Prune: ptr.To(true), SelfHeal: &selfHealEnabled},
+			SyncOptions: v1alpha1.SyncOptions{"RespectIgnoreDifferences=true"},
+		},
+		IgnoreDifferences: []v1alpha1.ResourceIgnoreDifferences{
+			{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}},
+		},
+	}
+	want := &argocdv1alpha1.ApplicationSpec{
+		Project: testProjectName,
+		SyncPolicy: &argocdv1alpha1.SyncPolicy{
+			Automated:   &argocdv1alpha1.SyncPolicyAutomated{Prune: true, SelfHeal: true},
+			SyncOptions: argocdv1alpha1.SyncOptions{"RespectIgnoreDifferences=true"},
+		},
+		IgnoreDifferences: argocdv1alpha1.IgnoreDifferences{
+			{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}},
+		},
+	}
+
+	got := (&v1alpha1.ConverterImpl{}).ToArgoApplicationSpec(p)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(argocdv1alpha1.ApplicationDestination{})); diff != "" {
+		t.Errorf("ToArgoApplicationSpec(...): -want, +got:\n%s", diff)
+	}
+
+	if !IsApplicationUpToDate(p, &argocdv1alpha1.Application{Spec: *want}) {
+		t.Errorf("IsApplicationUpToDate(...): want true, got false")
+	}
+	withoutOption := want.DeepCopy()
+	withoutOption.SyncPolicy.SyncOptions = nil
+	if IsApplicationUpToDate(p, &argocdv1alpha1.Application{Spec: *withoutOption}) {
+		t.Errorf("IsApplicationUpToDate(...): removed sync option is not reported as drift")
+	}
+}
+
+func TestSkipValidation(t *testing.T) {
+	cr := Application(
+		withExternalName(testApplicationExternalName),
+		withAnnotations(map[string]string{v1alpha1.AnnotationKeySkipValidation: "true"}),
+	)
+
+	if diff := cmp.Diff(ptr.To(false), generateUpdateRepositoryOptions(cr, nil, nil).Validate); diff != "" {
+		t.Errorf("generateUpdateRepositoryOptions(...): -want validate, +got validate:\n%s", diff)
+	}
+
+	setApplicationWarnings(cr)
+	want := apisv1alpha1.ValidationWarning(fmt.Sprintf(warnFmtSkipValidation, v1alpha1.AnnotationKeySkipValidation))
+	if diff := cmp.Diff(want, cr.Status.GetCondition(apisv1alpha1.TypeWarning), test.EquateConditions()); diff != "" {
+		t.Errorf("setApplicationWarnings(...): -want, +got:\n%s", diff)
+	}
+
+	meta.RemoveAnnotations(cr, v1alpha1.AnnotationKeySkipValidation)
+	if got := generateUpdateRepositoryOptions(cr, nil, nil).Validate; got != nil {
+		t.Errorf("generateUpdateRepositoryOptions(...): want validate unset, got %t", *got)
+	}
+}
+
+func TestObserveSyncedRevision(t *testing.T) {
+	synced := []string{"", "1c3a9bfa1e8f0a6f5d5f0e3c9b7a5d3c1e2f4a6b", "8d2e6c4a0b9f7e5d3c1a2b4c6d8e0f1a3b5c7d9e"}
+	client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+		for _, rev := range synced {
+			mcs.EXPECT().List(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.ApplicationList{
+				Items: []argocdv1alpha1.Application{{
+					ObjectMeta: metav1.ObjectMeta{Name: testApplicationExternalName},
+					Spec: argocdv1alpha1.ApplicationSpec{
+						Project: testProjectName,
+						Source:  &argocdv1alpha1.ApplicationSource{RepoURL: repoURL, TargetRevision: testBranch},
+					},
+					Status: argocdv1alpha1.ApplicationStatus{
+						Sync: argocdv1alpha1.SyncStatus{Status: argocdv1alpha1.SyncStatusCodeSynced, Revision: rev},
+					},
+				}},
+			}, nil)
+		}
+	})
+	e := &external{client: client}
+
+	for _, rev := range synced {
+		cr := Application(
+			withExternalName(testApplicationExternalName),
+			withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+		)
+		got, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("Observe(...): %v", err)
+		}
+		// The synced revision is published rather than the target revision.
+		var want managed.ConnectionDetails
+		if rev != "" {
+			want = managed.ConnectionDetails{connectionKeySyncedRevision: []byte(rev)}
+		}
+		if diff := cmp.Diff(want, got.ConnectionDetails); diff != "" {
+			t.Errorf("Observe(...) revision %q: -want, +got:\n%s", rev, diff)
+		}
+	}
+}
+
+func TestObserveApplicationSetOwned(t *testing.T) {
+	type want struct {
+		ready  xpv1.ConditionReason
+		result managed.ExternalObservation
+	}
+
+	cases := map[string]struct {
+		cr   *v1alpha1.Application
+		want want
+	}{
+		"BackOff": {
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: "other"}),
+			),
+			want: want{
+				ready:  v1alpha1.ReasonOwnedByApplicationSet,
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ReleaseOnDeletion": {
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: "other"}),
+				func(r *v1alpha1.Application) { now := metav1.Now(); r.SetDeletionTimestamp(&now) },
+			),
+			want: want{
+				ready:  v1alpha1.ReasonOwnedByApplicationSet,
+				result: managed.ExternalObservation{ResourceExists: false, ResourceUpToDate: true},
+			},
+		},
+		"ManagedByOverride": {
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: "other"}),
+				withAnnotations(map[string]string{v1alpha1.AnnotationKeyManageApplicationSetOwned: "true"}),
+			),
+			want: want{
+				ready:  xpv1.ReasonAvailable,
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.ApplicationList{
+					Items: []argocdv1alpha1.Application{{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: testApplicationExternalName,
+							OwnerReferences: []metav1.OwnerReference{{
+								APIVersion: "argoproj.io/v1alpha1",
+								Kind:       "ApplicationSet",
+								Name:       "podinfo-per-cluster",
+							}},
+						},
+						Spec: argocdv1alpha1.ApplicationSpec{Project: testProjectName},
+					}},
+				}, nil)
+			})
+
+			e := &external{client: client}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ready, tc.cr.Status.GetCondition(xpv1.TypeReady).Reason); diff != "" {
+				t.Errorf("Observe(...) Ready reason: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func globError(pattern string) error {
+	_, err := glob.Compile(pattern)
+	return err
+}
+
+func TestValidateDirectoryGlobs(t *testing.T) {
+	cases := map[string]struct {
+		directory *v1alpha1.ApplicationSourceDirectory
+		want      error
+	}{
+		"NoDirectory": {},
+		"NoPatterns": {
+			directory: &v1alpha1.ApplicationSourceDirectory{},
+		},
+		"ValidGlobs": {
+			directory: &v1alpha1.ApplicationSourceDirectory{
+				Include: clients.StringToPtr("{*.yaml,*.json}"),
+				Exclude: clients.StringToPtr("config/**/secret-*.yaml"),
+			},
+		},
+		"MalformedInclude": {
+			directory: &v1alpha1.ApplicationSourceDirectory{Include: &testMalformedGlob},
+			want:      errors.Wrapf(globError(testMalformedGlob), errFmtDirectoryGlob, "include", testMalformedGlob),
+		},
+		"MalformedExclude": {
+			directory: &v1alpha1.ApplicationSourceDirectory{Include: clients.StringToPtr("*.yaml"), Exclude: clients.StringToPtr("[a-")},
+			want:      errors.Wrapf(globError("[a-"), errFmtDirectoryGlob, "exclude", "[a-"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateDirectoryGlobs(tc.directory)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("validateDirectoryGlobs(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.Application
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.Application{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+						}, nil)
+				}),
+				cr: Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"SuccessfulSkipValidation": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+							},
+							Validate: ptr.To(false),
+						},
+					).Return(
+						&argocdv1alpha1.Application{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+					withAnnotations(map[string]string{v1alpha1.AnnotationKeySkipValidation: "true"}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+					withAnnotations(map[string]string{v1alpha1.AnnotationKeySkipValidation: "true"}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"SuccessfulZeroRevisionHistoryLimit": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									RevisionHistoryLimit: &zeroRevisionHistoryLimit,
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.Application{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						RevisionHistoryLimit: &zeroRevisionHistoryLimit,
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						RevisionHistoryLimit: &zeroRevisionHistoryLimit,
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"SuccessfulHelmRepoChart": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:        helmRepoURL,
+										Chart:          chartName,
+										TargetRevision: chartVersion,
+									},
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.Application{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        helmRepoURL,
+							Chart:          &chartName,
+							TargetRevision: &chartVersion,
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:        helmRepoURL,
+							Chart:          &chartName,
+							TargetRevision: &chartVersion,
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"SuccessfulRefOnlyValueSource": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Sources: argocdv1alpha1.ApplicationSources{
+										{
+											RepoURL:        helmRepoURL,
+											Chart:          chartName,
+											TargetRevision: chartVersion,
+										},
+										{
+											RepoURL: repoURL,
+											Ref:     testValuesRef,
+										},
+									},
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.Application{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL:        helmRepoURL,
+								Chart:          &chartName,
+								TargetRevision: &chartVersion,
+							},
+							{
+								RepoURL: repoURL,
+								Ref:     &testValuesRef,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Sources: v1alpha1.ApplicationSources{
+							{
+								RepoURL:        helmRepoURL,
+								Chart:          &chartName,
+								TargetRevision: &chartVersion,
+							},
+							{
+								RepoURL: repoURL,
+								Ref:     &testValuesRef,
+							},
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"ChartAndPath": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: helmRepoURL,
+							Chart:   &chartName,
+							Path:    &chartPath,
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: helmRepoURL,
+							Chart:   &chartName,
+							Path:    &chartPath,
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.New(errChartAndPath),
+			},
+		},
+		"SuccessfulKustomizeNamespace": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL:   repoURL,
+										Path:      chartPath,
+										Kustomize: &argocdv1alpha1.ApplicationSourceKustomize{Namespace: testKustomizeNamespace},
+									},
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.Application{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:   repoURL,
+							Path:      &chartPath,
+							Kustomize: &v1alpha1.ApplicationSourceKustomize{Namespace: &testKustomizeNamespace},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:   repoURL,
+							Path:      &chartPath,
+							Kustomize: &v1alpha1.ApplicationSourceKustomize{Namespace: &testKustomizeNamespace},
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"SuccessfulNamelessPlugin": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Source: &argocdv1alpha1.ApplicationSource{
+										RepoURL: repoURL,
+										Path:    chartPath,
+										Plugin: &argocdv1alpha1.ApplicationSourcePlugin{
+											Env: argocdv1alpha1.Env{{Name: "STAGE", Value: "prod"}},
+										},
+									},
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.Application{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+						}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Path:    &chartPath,
+							Plugin: &v1alpha1.ApplicationSourcePlugin{
+								Env: v1alpha1.Env{{Name: "STAGE", Value: "prod"}},
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Path:    &chartPath,
+							Plugin: &v1alpha1.ApplicationSourcePlugin{
+								Env: v1alpha1.Env{{Name: "STAGE", Value: "prod"}},
+							},
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    nil,
+			},
+		},
+		"EmptyPluginName": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Path:    &chartPath,
+							Plugin:  &v1alpha1.ApplicationSourcePlugin{Name: new(string)},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL: repoURL,
+							Path:    &chartPath,
+							Plugin:  &v1alpha1.ApplicationSourcePlugin{Name: new(string)},
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.New(errEmptyPluginName),
+			},
+		},
+		"MalformedDirectoryGlob": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:   repoURL,
+							Path:      &chartPath,
+							Directory: &v1alpha1.ApplicationSourceDirectory{Include: &testMalformedGlob},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Source: &v1alpha1.ApplicationSource{
+							RepoURL:   repoURL,
+							Path:      &chartPath,
+							Directory: &v1alpha1.ApplicationSourceDirectory{Include: &testMalformedGlob},
+						},
+					}),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrapf(globError(testMalformedGlob), errFmtDirectoryGlob, "include", testMalformedGlob),
+			},
+		},
+		"CreateSystemFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&argocdApplication.ApplicationCreateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+							},
+						},
+					).Return(
+						nil, errBoom)
+				}),
+				cr: Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+			},
+			want: want{
+				cr:     Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errBoom, errCreateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client}
+			o, err := e.Create(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
 }
 
-func initializedArgoAppStatus() v1alpha1.ArgoApplicationStatus {
-	return v1alpha1.ArgoApplicationStatus{
-		Resources: nil,
-		Sync: v1alpha1.SyncStatus{
-			Revision: &emptyString,
-			ComparedTo: v1alpha1.ComparedTo{
-				Source: v1alpha1.ApplicationSource{
-					Path:           &emptyString,
-					TargetRevision: &emptyString,
-					Chart:          &emptyString,
-					Ref:            &emptyString,
-				},
-				Destination: v1alpha1.ApplicationDestination{
-					Server:    &emptyString,
-					Namespace: &emptyString,
-					Name:      &emptyString,
+// eventRecorder sends the recorded events to its channel.
+type eventRecorder chan event.Event
+
+func (r eventRecorder) Event(_ runtime.Object, e event.Event) { r <- e }
+
+func (r eventRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func TestSyncAfterCreate(t *testing.T) {
+	type want struct {
+		sync  bool
+		retry *argocdv1alpha1.RetryStrategy
+		event *event.Event
+	}
+
+	cases := map[string]struct {
+		cr      *v1alpha1.Application
+		syncErr error
+		want    want
+	}{
+		"SyncStarted": {
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName, SyncAfterCreate: ptr.To(true)}),
+			),
+			want: want{
+				sync:  true,
+				event: ptr.To(event.Normal(reasonSyncAfterCreate, "Started sync of created application")),
+			},
+		},
+		"SyncFailed": {
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName, SyncAfterCreate: ptr.To(true)}),
+			),
+			syncErr: errBoom,
+			want: want{
+				sync:  true,
+				event: ptr.To(event.Warning(reasonSyncAfterCreate, errors.Wrap(errBoom, errSyncAfterCreate))),
+			},
+		},
+		"SyncWithRetry": {
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{
+					Project:         testProjectName,
+					SyncAfterCreate: ptr.To(true),
+					SyncPolicy: &v1alpha1.SyncPolicy{
+						Retry: &v1alpha1.RetryStrategy{
+							Limit:   ptr.To[int64](3),
+							Backoff: &v1alpha1.Backoff{Duration: ptr.To("5s"), Factor: ptr.To[int64](2)},
+						},
+					},
+				}),
+			),
+			want: want{
+				sync: true,
+				retry: &argocdv1alpha1.RetryStrategy{
+					Limit:   3,
+					Backoff: &argocdv1alpha1.Backoff{Duration: "5s", Factor: ptr.To[int64](2)},
 				},
+				event: ptr.To(event.Normal(reasonSyncAfterCreate, "Started sync of created application")),
 			},
 		},
-		Health: v1alpha1.HealthStatus{
-			Message: &emptyString,
+		"SyncDisabled": {
+			cr: Application(
+				withExternalName(testApplicationExternalName),
+				withSpec(v1alpha1.ApplicationParameters{Project: testProjectName}),
+			),
 		},
-		SourceType:           "",
-		Summary:              v1alpha1.ApplicationSummary{},
-		ResourceHealthSource: "",
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.Application{}, nil)
+				if tc.want.sync {
+					mcs.EXPECT().Sync(gomock.Any(), &argocdApplication.ApplicationSyncRequest{Name: &testApplicationExternalName, RetryStrategy: tc.want.retry}).Return(&argocdv1alpha1.Application{}, tc.syncErr)
+				}
+			})
+			recorder := make(eventRecorder, 1)
+			e := &external{client: client, log: logging.NewNopLogger(), recorder: recorder}
+
+			// A failed sync does not fail Create, since the application exists.
+			if _, err := e.Create(context.Background(), tc.cr); err != nil {
+				t.Errorf("e.Create(...): %s", err)
+			}
+			var got *event.Event
+			select {
+			case ev := <-recorder:
+				got = &ev
+			default:
+			}
+			if diff := cmp.Diff(tc.want.event, got, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want event, +got event:\n%s", diff)
+			}
+		})
 	}
 }
 
-func TestCreate(t *testing.T) {
+func TestUpdate(t *testing.T) {
 	type want struct {
 		cr     *v1alpha1.Application
-		result managed.ExternalCreation
+		result managed.ExternalUpdate
 		err    error
 	}
 
@@ -407,90 +3119,279 @@ func TestCreate(t *testing.T) {
 		"Successful": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
-					mcs.EXPECT().Create(
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(&argocdv1alpha1.ApplicationList{}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+									Destination: argocdv1alpha1.ApplicationDestination{
+										Namespace: testDestinationNamespace,
+									},
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: testApplicationExternalName,
+						},
+					}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Destination: v1alpha1.ApplicationDestination{
+							Namespace: &testDestinationNamespace,
+						},
+					}),
+					withExternalName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Destination: v1alpha1.ApplicationDestination{
+							Namespace: &testDestinationNamespace,
+						},
+					}),
+					withExternalName(testApplicationExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SuccessfulWithLabels": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(&argocdv1alpha1.ApplicationList{
+						Items: []argocdv1alpha1.Application{{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   testApplicationExternalName,
+								Labels: map[string]string{"team": "b", "owner": "c", testArgoCDLabel: "true"},
+							},
+							Spec: argocdv1alpha1.ApplicationSpec{
+								Project: testProjectName,
+							},
+						}},
+					}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"team": "a", "owner": "c", testArgoCDLabel: "true"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
+								},
+							},
+						},
+					).Return(&argocdv1alpha1.Application{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: testApplicationExternalName,
+						},
+					}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  testLabels,
+					}),
+				),
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  testLabels,
+					}),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SuccessfulWithLabelRemoved": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
 						context.Background(),
-						&argocdApplication.ApplicationCreateRequest{
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(&argocdv1alpha1.ApplicationList{
+						Items: []argocdv1alpha1.Application{{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   testApplicationExternalName,
+								Labels: map[string]string{"team": "a", "owner": "b", "other": "c", testArgoCDLabel: "true"},
+							},
+							Spec: argocdv1alpha1.ApplicationSpec{
+								Project: testProjectName,
+							},
+						}},
+					}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
 							Application: &argocdv1alpha1.Application{
 								ObjectMeta: metav1.ObjectMeta{
-									Name: testApplicationExternalName,
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"team": "a", "other": "c", testArgoCDLabel: "true"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
 								},
 							},
 						},
-					).Return(
-						&argocdv1alpha1.Application{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: testApplicationExternalName,
-							},
-						}, nil)
+					).Return(&argocdv1alpha1.Application{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: testApplicationExternalName,
+						},
+					}, nil)
 				}),
-				cr: Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  testLabels,
+					}),
+					withManagedLabels("owner", "team"),
+				),
 			},
 			want: want{
 				cr: Application(
 					withExternalName(testApplicationExternalName),
-					withName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+						Labels:  testLabels,
+					}),
+					withManagedLabels("owner", "team"),
 				),
-				result: managed.ExternalCreation{},
+				result: managed.ExternalUpdate{},
 				err:    nil,
 			},
 		},
-		"CreateSystemFailed": {
+		"SuccessfulWithoutLabels": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
-					mcs.EXPECT().Create(
+					mcs.EXPECT().List(
 						context.Background(),
-						&argocdApplication.ApplicationCreateRequest{
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(&argocdv1alpha1.ApplicationList{
+						Items: []argocdv1alpha1.Application{{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   testApplicationExternalName,
+								Labels: map[string]string{"owner": "c", testArgoCDLabel: "true"},
+							},
+							Spec: argocdv1alpha1.ApplicationSpec{
+								Project: testProjectName,
+							},
+						}},
+					}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
 							Application: &argocdv1alpha1.Application{
 								ObjectMeta: metav1.ObjectMeta{
-									Name: testApplicationExternalName,
+									Name:   testApplicationExternalName,
+									Labels: map[string]string{"owner": "c", testArgoCDLabel: "true"},
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testProjectName,
 								},
 							},
 						},
-					).Return(
-						nil, errBoom)
+					).Return(&argocdv1alpha1.Application{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: testApplicationExternalName,
+						},
+					}, nil)
 				}),
-				cr: Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+				),
 			},
 			want: want{
-				cr:     Application(withExternalName(testApplicationExternalName), withName(testApplicationExternalName)),
-				result: managed.ExternalCreation{},
-				err:    errors.Wrap(errBoom, errCreateFailed),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
-			o, err := e.Create(context.Background(), tc.args.cr)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.result, o); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestUpdate(t *testing.T) {
-	type want struct {
-		cr     *v1alpha1.Application
-		result managed.ExternalUpdate
-		err    error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"Successful": {
+		"ListFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(nil, errBoom)
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    errors.Wrap(errBoom, errListFailed),
+			},
+		},
+		"ProjectMoved": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(&argocdv1alpha1.ApplicationList{
+						Items: []argocdv1alpha1.Application{{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+							Spec: argocdv1alpha1.ApplicationSpec{
+								Project: testProjectName,
+							},
+						}},
+					}, nil)
 					mcs.EXPECT().Update(
 						context.Background(),
 						&argocdApplication.ApplicationUpdateRequest{
@@ -499,10 +3400,7 @@ func TestUpdate(t *testing.T) {
 									Name: testApplicationExternalName,
 								},
 								Spec: argocdv1alpha1.ApplicationSpec{
-									Project: testProjectName,
-									Destination: argocdv1alpha1.ApplicationDestination{
-										Namespace: testDestinationNamespace,
-									},
+									Project: testOtherProjectName,
 								},
 							},
 						},
@@ -510,37 +3408,89 @@ func TestUpdate(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Name: testApplicationExternalName,
 						},
+						Spec: argocdv1alpha1.ApplicationSpec{
+							Project: testOtherProjectName,
+						},
 					}, nil)
 				}),
 				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testOtherProjectName,
+					}),
 					withExternalName(testApplicationExternalName),
+				),
+			},
+			want: want{
+				cr: Application(
 					withSpec(v1alpha1.ApplicationParameters{
-						Project: testProjectName,
-						Destination: v1alpha1.ApplicationDestination{
-							Namespace: &testDestinationNamespace,
+						Project: testOtherProjectName,
+					}),
+					withExternalName(testApplicationExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"ProjectNotFound": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(&argocdv1alpha1.ApplicationList{
+						Items: []argocdv1alpha1.Application{{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testApplicationExternalName,
+							},
+							Spec: argocdv1alpha1.ApplicationSpec{
+								Project: testProjectName,
+							},
+						}},
+					}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						&argocdApplication.ApplicationUpdateRequest{
+							Application: &argocdv1alpha1.Application{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testApplicationExternalName,
+								},
+								Spec: argocdv1alpha1.ApplicationSpec{
+									Project: testMissingProjectName,
+								},
+							},
 						},
+					).Return(nil, errProjectNotFound)
+				}),
+				cr: Application(
+					withSpec(v1alpha1.ApplicationParameters{
+						Project: testMissingProjectName,
 					}),
 					withExternalName(testApplicationExternalName),
 				),
 			},
 			want: want{
 				cr: Application(
-					withExternalName(testApplicationExternalName),
 					withSpec(v1alpha1.ApplicationParameters{
-						Project: testProjectName,
-						Destination: v1alpha1.ApplicationDestination{
-							Namespace: &testDestinationNamespace,
-						},
+						Project: testMissingProjectName,
 					}),
 					withExternalName(testApplicationExternalName),
+					withConditions(v1alpha1.ProjectMismatch(`application is in project "default" instead of "missing": appproject.argoproj.io "missing" not found`)),
 				),
 				result: managed.ExternalUpdate{},
-				err:    nil,
+				err:    errors.Wrap(errProjectNotFound, errUpdateFailed),
 			},
 		},
 		"UpdateFailed": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().List(
+						context.Background(),
+						&argocdApplication.ApplicationQuery{
+							Name: &testApplicationExternalName,
+						},
+					).Return(&argocdv1alpha1.ApplicationList{}, nil)
 					mcs.EXPECT().Update(
 						context.Background(),
 						&argocdApplication.ApplicationUpdateRequest{
@@ -626,6 +3576,58 @@ func TestDelete(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessfulInDefaultAppNamespace": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Delete(
+						context.Background(),
+						&argocdApplication.ApplicationDeleteRequest{
+							Name:         &testApplicationExternalName,
+							AppNamespace: &testAppNamespace,
+						},
+					).Return(&argocdApplication.ApplicationResponse{}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+				),
+				defaultAppNamespace: testAppNamespace,
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+				),
+				err: nil,
+			},
+		},
+		"SuccessfulInOverriddenAppNamespace": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Delete(
+						context.Background(),
+						&argocdApplication.ApplicationDeleteRequest{
+							Name:         &testApplicationExternalName,
+							AppNamespace: &testAppNamespace,
+						},
+					).Return(&argocdApplication.ApplicationResponse{}, nil)
+				}),
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{AppNamespace: &testAppNamespace}),
+				),
+				defaultAppNamespace: "argocd",
+			},
+			want: want{
+				cr: Application(
+					withExternalName(testApplicationExternalName),
+					withName(testApplicationExternalName),
+					withSpec(v1alpha1.ApplicationParameters{AppNamespace: &testAppNamespace}),
+				),
+				err: nil,
+			},
+		},
 		"DeleteFailed": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
@@ -653,7 +3655,7 @@ func TestDelete(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
+			e := &external{client: tc.client, defaultAppNamespace: tc.defaultAppNamespace}
 			err := e.Delete(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -22,23 +22,32 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/applications"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/certificates"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/cluster"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/config"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/gpgkeys"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/options"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/projects"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/repocreds"
 	"github.com/crossplane-contrib/provider-argocd/pkg/controller/repositories"
 )
 
-// Setup creates all argocd API controllers with the supplied logger and adds
-// them to the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
-		config.Setup,
+// Setup creates all argocd API controllers with the supplied logger and
+// options and adds them to the supplied manager.
+func Setup(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
+	for _, setup := range []func(ctrl.Manager, logging.Logger, options.Options) error{
+		func(mgr ctrl.Manager, l logging.Logger, _ options.Options) error {
+			return config.Setup(mgr, l)
+		},
 		repositories.SetupRepository,
+		repocreds.SetupRepositoryCredentials,
+		gpgkeys.SetupGPGKey,
+		certificates.SetupCertificate,
 		projects.SetupProject,
 		cluster.SetupCluster,
 		applications.SetupApplication,
 	} {
-		if err := setup(mgr, l); err != nil {
+		if err := setup(mgr, l, o); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,254 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/certificate"
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	certutil "github.com/argoproj/argo-cd/v2/util/cert"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/certificates/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	certificatesclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/certificates"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/options"
+	"github.com/crossplane-contrib/provider-argocd/pkg/jitter"
+	"github.com/crossplane-contrib/provider-argocd/pkg/metrics"
+	"github.com/crossplane-contrib/provider-argocd/pkg/shard"
+	"github.com/crossplane-contrib/provider-argocd/pkg/tracing"
+)
+
+const (
+	errNotCertificate     = "managed resource is not a Argocd certificate custom resource"
+	errListFailed         = "cannot list Argocd certificates"
+	errCreateFailed       = "cannot create Argocd certificate"
+	errUpdateFailed       = "cannot update Argocd certificate"
+	errDeleteFailed       = "cannot delete Argocd certificate"
+	errSSHSubTypeRequired = "certSubType is required for SSH known host keys"
+	errInvalidSSHKey      = "certData is no valid SSH key"
+	errInvalidTLSCert     = "certData is no valid PEM encoded TLS certificate"
+)
+
+// sshFingerprintFmt is the format ArgoCD lists the fingerprints of SSH keys in.
+const sshFingerprintFmt = "SHA256:%s"
+
+// SetupCertificate adds a controller that reconciles repository server
+// certificates.
+func SetupCertificate(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.CertificateKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		Watches(&source.Kind{Type: &v1alpha1.Certificate{}}, jitter.NewEventHandler(o.Jitter.For(v1alpha1.CertificateKind)), builder.WithPredicates(shard.NewPredicate(o.ShardSelector))).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.CertificateGroupVersionKind),
+			managed.WithExternalConnecter(tracing.NewConnecter(v1alpha1.CertificateKind, metrics.NewDriftConnecter(v1alpha1.CertificateKind, &connector{kube: mgr.GetClient(), pool: o.Pool, newArgocdClientFn: certificatesclient.NewCertificateServiceClient}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type connector struct {
+	kube              client.Client
+	pool              *clients.Pool
+	newArgocdClientFn func(c *clients.PooledClient) (certificate.CertificateServiceClient, error)
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Certificate)
+	if !ok {
+		return nil, errors.New(errNotCertificate)
+	}
+	pc, err := c.pool.Connect(ctx, c.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	argocdClient, err := c.newArgocdClientFn(pc)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: argocdClient}, nil
+}
+
+type external struct {
+	client certificatesclient.ServiceClient
+}
+
+// Observe finds the certificates of the server name and type. ArgoCD does not
+// return the certificate data, so SSH keys are compared by their fingerprint
+// and TLS certificates by their subjects.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Certificate)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCertificate)
+	}
+	p := &cr.Spec.ForProvider
+
+	list, err := e.client.ListCertificates(ctx, generateCertificateQuery(p))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(clients.HandleMaintenance(cr, err), errListFailed)
+	}
+	// The host name of the query is a glob pattern.
+	var observed []argocdv1alpha1.RepositoryCertificate
+	for _, c := range list.Items {
+		if c.ServerName == p.ServerName && c.CertType == p.CertType {
+			observed = append(observed, c)
+		}
+	}
+	if len(observed) == 0 {
+		return managed.ExternalObservation{}, nil
+	}
+
+	desired, err := certInfo(p)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.AtProvider = generateCertificateObservation(observed)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: cmp.Equal(desired, cr.Status.AtProvider.CertInfo, cmpopts.SortSlices(func(a, b string) bool { return a < b })),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Certificate)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCertificate)
+	}
+
+	req, err := generateCreateCertificateRequest(&cr.Spec.ForProvider, false)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	_, err = e.client.CreateCertificate(ctx, req)
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+}
+
+// Update replaces the certificates of the server name and type.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Certificate)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCertificate)
+	}
+
+	req, err := generateCreateCertificateRequest(&cr.Spec.ForProvider, true)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	_, err = e.client.CreateCertificate(ctx, req)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+// Delete removes the certificates of the server name and type. A certificate
+// that is already gone is no error.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Certificate)
+	if !ok {
+		return errors.New(errNotCertificate)
+	}
+
+	_, err := e.client.DeleteCertificate(ctx, generateCertificateQuery(&cr.Spec.ForProvider))
+	if certificatesclient.IsErrorCertificateNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errDeleteFailed)
+}
+
+func generateCertificateQuery(p *v1alpha1.CertificateParameters) *certificate.RepositoryCertificateQuery {
+	q := &certificate.RepositoryCertificateQuery{
+		HostNamePattern: p.ServerName,
+		CertType:        p.CertType,
+	}
+	if p.CertType == v1alpha1.CertTypeSSH {
+		q.CertSubType = clients.StringValue(p.CertSubType)
+	}
+	return q
+}
+
+func generateCreateCertificateRequest(p *v1alpha1.CertificateParameters, upsert bool) (*certificate.RepositoryCertificateCreateRequest, error) {
+	c := argocdv1alpha1.RepositoryCertificate{
+		ServerName: p.ServerName,
+		CertType:   p.CertType,
+		CertData:   []byte(p.CertData),
+	}
+	if p.CertType == v1alpha1.CertTypeSSH {
+		if clients.StringValue(p.CertSubType) == "" {
+			return nil, errors.New(errSSHSubTypeRequired)
+		}
+		c.CertSubType = *p.CertSubType
+	}
+	return &certificate.RepositoryCertificateCreateRequest{
+		Certificates: &argocdv1alpha1.RepositoryCertificateList{Items: []argocdv1alpha1.RepositoryCertificate{c}},
+		Upsert:       upsert,
+	}, nil
+}
+
+func generateCertificateObservation(certs []argocdv1alpha1.RepositoryCertificate) v1alpha1.CertificateObservation {
+	o := v1alpha1.CertificateObservation{}
+	for _, c := range certs {
+		o.CertSubTypes = append(o.CertSubTypes, c.CertSubType)
+		o.CertInfo = append(o.CertInfo, c.CertInfo)
+	}
+	return o
+}
+
+// certInfo returns the certificate info ArgoCD lists for the supplied
+// parameters, i.e. the fingerprint of an SSH key in the format of ArgoCD or
+// the subjects of the TLS certificates.
+func certInfo(p *v1alpha1.CertificateParameters) ([]string, error) {
+	if p.CertType == v1alpha1.CertTypeSSH {
+		fp := certutil.SSHFingerprintSHA256FromString(fmt.Sprintf("%s %s", p.ServerName, p.CertData))
+		if fp == "" {
+			return nil, errors.New(errInvalidSSHKey)
+		}
+		return []string{fmt.Sprintf(sshFingerprintFmt, fp)}, nil
+	}
+
+	pems, err := certutil.ParseTLSCertificatesFromData(p.CertData)
+	if err != nil {
+		return nil, errors.Wrap(err, errInvalidTLSCert)
+	}
+	if len(pems) == 0 {
+		return nil, errors.New(errInvalidTLSCert)
+	}
+	subjects := make([]string, 0, len(pems))
+	for _, pem := range pems {
+		x, err := certutil.DecodePEMCertificateToX509(pem)
+		if err != nil {
+			return nil, errors.Wrap(err, errInvalidTLSCert)
+		}
+		subjects = append(subjects, x.Subject.String())
+	}
+	return subjects, nil
+}
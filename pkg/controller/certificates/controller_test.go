@@ -0,0 +1,298 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/certificate"
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/certificates/v1alpha1"
+	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/certificates"
+)
+
+var (
+	errBoom           = errors.New("boom")
+	testServerName    = "github.com"
+	testSSHSubType    = "ssh-ed25519"
+	testSSHKey        = "AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+	testSSHFinger     = "SHA256:+DiY3wvvV6TuJJhbpZisF/zLDA0zPMSvHdkr4UvCOqU"
+	testTLSServerName = "git.example.org"
+)
+
+type mockModifier func(*mockclient.MockServiceClient)
+
+func withMockClient(t *testing.T, mod mockModifier) *mockclient.MockServiceClient {
+	ctrl := gomock.NewController(t)
+	mock := mockclient.NewMockServiceClient(ctrl)
+	mod(mock)
+	return mock
+}
+
+// selfSignedPEM returns a PEM encoded self-signed certificate with the
+// supplied common name.
+func selfSignedPEM(t *testing.T, cn string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func sshCertificate() *v1alpha1.Certificate {
+	return &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{ForProvider: v1alpha1.CertificateParameters{
+		ServerName:  testServerName,
+		CertType:    v1alpha1.CertTypeSSH,
+		CertSubType: &testSSHSubType,
+		CertData:    testSSHKey,
+	}}}
+}
+
+func tlsCertificate(data string) *v1alpha1.Certificate {
+	return &v1alpha1.Certificate{Spec: v1alpha1.CertificateSpec{ForProvider: v1alpha1.CertificateParameters{
+		ServerName: testTLSServerName,
+		CertType:   v1alpha1.CertTypeHTTPS,
+		CertData:   data,
+	}}}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		result managed.ExternalObservation
+		obs    v1alpha1.CertificateObservation
+		err    error
+	}
+
+	sshQuery := &certificate.RepositoryCertificateQuery{HostNamePattern: testServerName, CertType: v1alpha1.CertTypeSSH, CertSubType: testSSHSubType}
+	tlsQuery := &certificate.RepositoryCertificateQuery{HostNamePattern: testTLSServerName, CertType: v1alpha1.CertTypeHTTPS}
+	listing := func(q *certificate.RepositoryCertificateQuery, items ...argocdv1alpha1.RepositoryCertificate) mockModifier {
+		return func(mcs *mockclient.MockServiceClient) {
+			mcs.EXPECT().ListCertificates(context.Background(), q).
+				Return(&argocdv1alpha1.RepositoryCertificateList{Items: items}, nil)
+		}
+	}
+
+	cases := map[string]struct {
+		client mockModifier
+		cr     *v1alpha1.Certificate
+		want   want
+	}{
+		"NotFound": {
+			client: listing(sshQuery),
+			cr:     sshCertificate(),
+			want:   want{result: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"OtherServerIgnored": {
+			client: listing(sshQuery, argocdv1alpha1.RepositoryCertificate{ServerName: "[github.com]:2222", CertType: "ssh", CertSubType: testSSHSubType, CertInfo: testSSHFinger}),
+			cr:     sshCertificate(),
+			want:   want{result: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"ListFailed": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().ListCertificates(context.Background(), sshQuery).Return(nil, errBoom)
+			},
+			cr:   sshCertificate(),
+			want: want{err: errors.Wrap(errBoom, errListFailed)},
+		},
+		"SSHUpToDate": {
+			client: listing(sshQuery, argocdv1alpha1.RepositoryCertificate{ServerName: testServerName, CertType: "ssh", CertSubType: testSSHSubType, CertInfo: testSSHFinger}),
+			cr:     sshCertificate(),
+			want: want{
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs:    v1alpha1.CertificateObservation{CertSubTypes: []string{testSSHSubType}, CertInfo: []string{testSSHFinger}},
+			},
+		},
+		"SSHKeyChanged": {
+			client: listing(sshQuery, argocdv1alpha1.RepositoryCertificate{ServerName: testServerName, CertType: "ssh", CertSubType: testSSHSubType, CertInfo: "SHA256:stale"}),
+			cr:     sshCertificate(),
+			want: want{
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				obs:    v1alpha1.CertificateObservation{CertSubTypes: []string{testSSHSubType}, CertInfo: []string{"SHA256:stale"}},
+			},
+		},
+		"TLSUpToDate": {
+			client: listing(tlsQuery, argocdv1alpha1.RepositoryCertificate{ServerName: testTLSServerName, CertType: "https", CertSubType: "ecdsa", CertInfo: "CN=git.example.org"}),
+			cr:     tlsCertificate(selfSignedPEM(t, testTLSServerName)),
+			want: want{
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs:    v1alpha1.CertificateObservation{CertSubTypes: []string{"ecdsa"}, CertInfo: []string{"CN=git.example.org"}},
+			},
+		},
+		"TLSCertificateAdded": {
+			client: listing(tlsQuery, argocdv1alpha1.RepositoryCertificate{ServerName: testTLSServerName, CertType: "https", CertSubType: "ecdsa", CertInfo: "CN=git.example.org"}),
+			cr:     tlsCertificate(selfSignedPEM(t, testTLSServerName) + selfSignedPEM(t, "Example Root CA")),
+			want: want{
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				obs:    v1alpha1.CertificateObservation{CertSubTypes: []string{"ecdsa"}, CertInfo: []string{"CN=git.example.org"}},
+			},
+		},
+		"TLSInvalidData": {
+			client: listing(tlsQuery, argocdv1alpha1.RepositoryCertificate{ServerName: testTLSServerName, CertType: "https", CertSubType: "ecdsa", CertInfo: "CN=git.example.org"}),
+			cr:     tlsCertificate("not a certificate"),
+			want:   want{err: errors.New(errInvalidTLSCert)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: withMockClient(t, tc.client)}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("Observe(...): -want observation, +got observation:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		client mockModifier
+		cr     *v1alpha1.Certificate
+		want   error
+	}{
+		"SSH": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().CreateCertificate(context.Background(), &certificate.RepositoryCertificateCreateRequest{
+					Certificates: &argocdv1alpha1.RepositoryCertificateList{Items: []argocdv1alpha1.RepositoryCertificate{{
+						ServerName:  testServerName,
+						CertType:    v1alpha1.CertTypeSSH,
+						CertSubType: testSSHSubType,
+						CertData:    []byte(testSSHKey),
+					}}},
+				}).Return(&argocdv1alpha1.RepositoryCertificateList{}, nil)
+			},
+			cr: sshCertificate(),
+		},
+		"SSHSubTypeMissing": {
+			client: func(_ *mockclient.MockServiceClient) {},
+			cr: func() *v1alpha1.Certificate {
+				cr := sshCertificate()
+				cr.Spec.ForProvider.CertSubType = ptr.To("")
+				return cr
+			}(),
+			want: errors.New(errSSHSubTypeRequired),
+		},
+		"CreateFailed": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().CreateCertificate(context.Background(), gomock.Any()).Return(nil, errBoom)
+			},
+			cr:   sshCertificate(),
+			want: errors.Wrap(errBoom, errCreateFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: withMockClient(t, tc.client)}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	data := selfSignedPEM(t, testTLSServerName)
+	mcs := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+		mcs.EXPECT().CreateCertificate(context.Background(), &certificate.RepositoryCertificateCreateRequest{
+			Certificates: &argocdv1alpha1.RepositoryCertificateList{Items: []argocdv1alpha1.RepositoryCertificate{{
+				ServerName: testTLSServerName,
+				CertType:   v1alpha1.CertTypeHTTPS,
+				CertData:   []byte(data),
+			}}},
+			Upsert: true,
+		}).Return(&argocdv1alpha1.RepositoryCertificateList{}, nil)
+	})
+
+	e := &external{client: mcs}
+	if _, err := e.Update(context.Background(), tlsCertificate(data)); err != nil {
+		t.Errorf("Update(...): %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	query := &certificate.RepositoryCertificateQuery{HostNamePattern: testServerName, CertType: v1alpha1.CertTypeSSH, CertSubType: testSSHSubType}
+
+	cases := map[string]struct {
+		client mockModifier
+		want   error
+	}{
+		"Successful": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().DeleteCertificate(context.Background(), query).Return(&argocdv1alpha1.RepositoryCertificateList{}, nil)
+			},
+		},
+		"AlreadyAbsent": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().DeleteCertificate(context.Background(), query).Return(nil, status.Error(codes.NotFound, "not found"))
+			},
+		},
+		"DeleteFailed": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().DeleteCertificate(context.Background(), query).Return(nil, errBoom)
+			},
+			want: errors.Wrap(errBoom, errDeleteFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: withMockClient(t, tc.client)}
+			err := e.Delete(context.Background(), sshCertificate())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
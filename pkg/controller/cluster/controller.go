@@ -18,12 +18,14 @@ package cluster
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 
-	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	argocdcluster "github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -31,7 +33,9 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -43,6 +47,11 @@ import (
 	"github.com/crossplane-contrib/provider-argocd/apis/cluster/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/cluster"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/options"
+	"github.com/crossplane-contrib/provider-argocd/pkg/jitter"
+	"github.com/crossplane-contrib/provider-argocd/pkg/metrics"
+	"github.com/crossplane-contrib/provider-argocd/pkg/shard"
+	"github.com/crossplane-contrib/provider-argocd/pkg/tracing"
 )
 
 const (
@@ -54,18 +63,34 @@ const (
 	errGetSecretFailed = "cannot get Kubernetes secret"
 	errFmtKeyNotFound  = "key %s is not found in referenced Kubernetes secret"
 	errParseKubeconfig = "unable to parse kubeconfig"
+	errMetadataPending = "labels or annotations of the cluster are not applied yet"
+)
+
+var (
+	// argocdManagedLabels are set on the cluster secret by ArgoCD itself and
+	// are ignored when comparing labels.
+	argocdManagedLabels = []string{
+		"argocd.argoproj.io/secret-type",
+	}
+	// argocdManagedAnnotations are set on the cluster secret by ArgoCD or
+	// kubectl and are ignored when comparing annotations.
+	argocdManagedAnnotations = []string{
+		argocdv1alpha1.AnnotationKeyRefresh,
+		corev1.LastAppliedConfigAnnotation,
+		"managed-by",
+	}
 )
 
 // SetupCluster adds a controller that reconciles cluster.
-func SetupCluster(mgr ctrl.Manager, l logging.Logger) error {
+func SetupCluster(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.ClusterKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.Cluster{}).
+		Watches(&source.Kind{Type: &v1alpha1.Cluster{}}, jitter.NewEventHandler(o.Jitter.For(v1alpha1.ClusterKind)), builder.WithPredicates(shard.NewPredicate(o.ShardSelector))).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.ClusterGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: cluster.NewClusterServiceClient}),
+			managed.WithExternalConnecter(tracing.NewConnecter(v1alpha1.ClusterKind, metrics.NewDriftConnecter(v1alpha1.ClusterKind, &connector{kube: mgr.GetClient(), pool: o.Pool, lateInitialize: o.LateInitialize, newArgocdClientFn: cluster.NewClusterServiceClient}))),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
@@ -73,7 +98,9 @@ func SetupCluster(mgr ctrl.Manager, l logging.Logger) error {
 
 type connector struct {
 	kube              client.Client
-	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) argocdcluster.ClusterServiceClient
+	pool              *clients.Pool
+	newArgocdClientFn func(c *clients.PooledClient) (argocdcluster.ClusterServiceClient, error)
+	lateInitialize    bool
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -81,16 +108,21 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if !ok {
 		return nil, errors.New(errNotCluster)
 	}
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	pc, err := c.pool.Connect(ctx, c.kube, cr)
 	if err != nil {
 		return nil, err
 	}
-	return &external{kube: c.kube, client: c.newArgocdClientFn(cfg)}, nil
+	argocdClient, err := c.newArgocdClientFn(pc)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.kube, client: argocdClient, lateInitialize: c.lateInitialize}, nil
 }
 
 type external struct {
-	kube   client.Client
-	client cluster.ServiceClient
+	kube           client.Client
+	client         cluster.ServiceClient
+	lateInitialize bool
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint: gocyclo
@@ -131,7 +163,7 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 		default:
 			// Default case: Handle other errors
-			return managed.ExternalObservation{}, errors.Wrap(err, errGetFailed)
+			return managed.ExternalObservation{}, errors.Wrap(clients.HandleMaintenance(cr, err), errGetFailed)
 		}
 	}
 	if meta.WasDeleted(cr) && meta.GetExternalName(cr) != observedCluster.Name {
@@ -139,20 +171,45 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, nil
 	}
 
-	currentSpec := cr.Spec.ForProvider.DeepCopy()
-	lateInitializeCluster(&cr.Spec.ForProvider, observedCluster)
-
 	kubeconfigSecretResourceVersion, err := e.getSecretResourceVersion(ctx, cr.Spec.ForProvider.Config.KubeconfigSecretRef)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	bearerTokenHash, err := e.getBearerTokenHash(ctx, cr.Spec.ForProvider.Config.BearerTokenSecretRef)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 	currentStatusAtProvider := cr.Status.AtProvider.DeepCopy()
 	cr.Status.AtProvider = generateClusterObservation(observedCluster, kubeconfigSecretResourceVersion)
-	cr.Status.SetConditions(xpv1.Available())
+	// The hash is only updated once the token was written to ArgoCD. Status
+	// changes made by Create are not persisted, so a cluster without a hash is
+	// assumed to hold the current token, e.g. the one it was created with.
+	cr.Status.AtProvider.BearerTokenHash = currentStatusAtProvider.BearerTokenHash
+	if cr.Status.AtProvider.BearerTokenHash == "" {
+		cr.Status.AtProvider.BearerTokenHash = bearerTokenHash
+	}
+	// The labels and annotations are registered together with the cluster, so
+	// that ApplicationSet cluster generators never select a cluster with
+	// partial metadata. Until they are consistent the cluster is not ready.
+	if isEqualMetadata(cr.Spec.ForProvider.Labels, observedCluster.Labels, argocdManagedLabels) &&
+		isEqualMetadata(cr.Spec.ForProvider.Annotations, observedCluster.Annotations, argocdManagedAnnotations) {
+		cr.Status.SetConditions(xpv1.Available())
+	} else {
+		cr.Status.SetConditions(v1alpha1.MetadataPending(errMetadataPending))
+	}
+
+	// Without late initialization, unset fields are compared with the values
+	// ArgoCD defaulted, but these are never written to the spec.
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	desired := cr
+	if !clients.ShouldLateInitialize(cr, e.lateInitialize) {
+		desired = cr.DeepCopy()
+	}
+	lateInitializeCluster(&desired.Spec.ForProvider, observedCluster)
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        isClusterUpToDate(cr, currentStatusAtProvider, observedCluster),
+		ResourceUpToDate:        isClusterUpToDate(desired, currentStatusAtProvider, observedCluster) && bearerTokenHash == cr.Status.AtProvider.BearerTokenHash,
 		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
 	}, nil
 }
@@ -191,9 +248,17 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, err
 	}
 
-	_, err = e.client.Update(ctx, clusterUpdateRequest)
+	bearerTokenHash, err := e.getBearerTokenHash(ctx, cr.Spec.ForProvider.Config.BearerTokenSecretRef)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if _, err = e.client.Update(ctx, clusterUpdateRequest); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	cr.Status.AtProvider.BearerTokenHash = bearerTokenHash
+	return managed.ExternalUpdate{}, nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -294,6 +359,7 @@ func (e *external) convertClusterTypes(ctx context.Context, p *v1alpha1.ClusterP
 			argoCluster.Config.TLSClientConfig.ServerName = *p.Config.TLSClientConfig.ServerName
 		}
 		argoCluster.Config.TLSClientConfig.Insecure = p.Config.TLSClientConfig.Insecure
+		argoCluster.Config.TLSClientConfig.CAData = p.Config.TLSClientConfig.CAData
 	}
 
 	if p.Config.AWSAuthConfig != nil {
@@ -373,10 +439,10 @@ func isClusterUpToDate(cr *v1alpha1.Cluster, o *v1alpha1.ClusterObservation, r *
 	}
 	switch {
 	case !isEqualConfig(&p.Config, &r.Config),
-		!cmp.Equal(p.Namespaces, r.Namespaces),
+		!isEqualNamespaces(p.Namespaces, r.Namespaces),
 		!cmp.Equal(p.Shard, r.Shard),
-		!cmp.Equal(p.Labels, r.Labels),
-		!cmp.Equal(p.Annotations, r.Annotations),
+		!isEqualMetadata(p.Labels, r.Labels, argocdManagedLabels),
+		!isEqualMetadata(p.Annotations, r.Annotations, argocdManagedAnnotations),
 		!cmp.Equal(cr.Status.AtProvider.Kubeconfig, o.Kubeconfig):
 		return false
 	}
@@ -384,6 +450,32 @@ func isClusterUpToDate(cr *v1alpha1.Cluster, o *v1alpha1.ClusterObservation, r *
 	return true
 }
 
+// isEqualNamespaces compares the namespaces a cluster is scoped to regardless
+// of their order. No namespaces, whether unset or empty, scope the cluster to
+// all namespaces.
+func isEqualNamespaces(p, r []string) bool {
+	return cmp.Equal(p, r, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+// isEqualMetadata compares labels or annotations by content. Keys managed by
+// ArgoCD are ignored, and a nil map equals an empty one.
+func isEqualMetadata(p, r map[string]string, ignored []string) bool {
+	return cmp.Equal(withoutKeys(p, ignored), withoutKeys(r, ignored))
+}
+
+// withoutKeys returns a copy of m without the supplied keys. The copy is
+// never nil.
+func withoutKeys(m map[string]string, keys []string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	for _, k := range keys {
+		delete(out, k)
+	}
+	return out
+}
+
 func isEqualConfig(p *v1alpha1.ClusterConfig, r *argocdv1alpha1.ClusterConfig) bool {
 	if p == nil && r == nil {
 		return true
@@ -401,6 +493,8 @@ func isEqualConfig(p *v1alpha1.ClusterConfig, r *argocdv1alpha1.ClusterConfig) b
 	return true
 }
 
+// isEqualTLSConfig compares insecure and serverName only. ArgoCD redacts
+// cert, key and CA data, so they can't be compared.
 func isEqualTLSConfig(p *v1alpha1.TLSClientConfig, r *argocdv1alpha1.TLSClientConfig) bool {
 	if p == nil && r == nil {
 		return true
@@ -527,6 +621,21 @@ func (e *external) getSecretResourceVersion(ctx context.Context, ref *v1alpha1.S
 	return sc.GetResourceVersion(), nil
 }
 
+// getBearerTokenHash returns the SHA-256 hash of the referenced bearer token,
+// or an empty string if no token is referenced. ArgoCD does not return the
+// token, so its hash is recorded to detect a rotation.
+func (e *external) getBearerTokenHash(ctx context.Context, ref *v1alpha1.SecretReference) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	payload, err := e.getPayload(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // fetch kubernetes secret payload
 func (e *external) getPayload(ctx context.Context, ref *v1alpha1.SecretReference) ([]byte, error) {
 
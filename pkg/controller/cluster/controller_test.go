@@ -23,7 +23,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	argocdCluster "github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
@@ -34,6 +37,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/cluster/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/cluster"
 	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/cluster"
 )
@@ -45,6 +49,10 @@ var (
 	testClusterServer       = "https://example.com/"
 	testNamespaces          = [1]string{"default"}
 	testUsername            = "testuser"
+	testServerName          = "kubernetes.example.com"
+	testCertDataSecretRef   = v1alpha1.SecretReference{Name: "cluster-tls", Namespace: "crossplane-system", Key: "tls.crt"}
+	testKeyDataSecretRef    = v1alpha1.SecretReference{Name: "cluster-tls", Namespace: "crossplane-system", Key: "tls.key"}
+	testBearerTokenRef      = v1alpha1.SecretReference{Name: "cluster-token", Namespace: "crossplane-system", Key: "token"}
 )
 
 type args struct {
@@ -77,6 +85,10 @@ func withExternalName(v string) ClusterModifier {
 	}
 }
 
+func withAnnotations(a map[string]string) ClusterModifier {
+	return func(r *v1alpha1.Cluster) { meta.AddAnnotations(r, a) }
+}
+
 func withSpec(p v1alpha1.ClusterParameters) ClusterModifier {
 	return func(r *v1alpha1.Cluster) { r.Spec.ForProvider = p }
 }
@@ -234,6 +246,75 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"LateInitializationDisabled": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdCluster.ClusterQuery{
+							Name:   testClusterExternalName,
+							Server: testClusterServer,
+						},
+					).Return(
+						&argocdv1alpha1.Cluster{
+							Server:     testClusterServer,
+							Name:       testClusterExternalName,
+							Namespaces: testNamespaces[:],
+							Config: argocdv1alpha1.ClusterConfig{
+								TLSClientConfig: argocdv1alpha1.TLSClientConfig{
+									Insecure: true,
+								},
+							},
+						}, nil)
+				}),
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withAnnotations(map[string]string{clients.AnnotationKeyLateInitialize: "false"}),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure: true,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withAnnotations(map[string]string{clients.AnnotationKeyLateInitialize: "false"}),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure: true,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ClusterObservation{
+						ClusterInfo: v1alpha1.ClusterInfo{
+							ConnectionState: &v1alpha1.ConnectionState{},
+							ServerVersion:   new(string),
+							CacheInfo: &v1alpha1.ClusterCacheInfo{
+								ResourcesCount: new(int64),
+								APIsCount:      new(int64),
+							},
+							ApplicationsCount: 0,
+						},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
 		"LabelsNotUpToDate": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
@@ -302,6 +383,152 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"TLSCertDataIgnored": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdCluster.ClusterQuery{
+							Name:   testClusterExternalName,
+							Server: testClusterServer,
+						},
+					).Return(
+						&argocdv1alpha1.Cluster{
+							Server: testClusterServer,
+							Name:   testClusterExternalName,
+							Config: argocdv1alpha1.ClusterConfig{
+								TLSClientConfig: argocdv1alpha1.TLSClientConfig{
+									Insecure:   true,
+									ServerName: testServerName,
+									CertData:   []byte("redacted"),
+									KeyData:    []byte("redacted"),
+								},
+							},
+						}, nil)
+				}),
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure:          true,
+								ServerName:        ptr.To(testServerName),
+								CertDataSecretRef: &testCertDataSecretRef,
+								KeyDataSecretRef:  &testKeyDataSecretRef,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure:          true,
+								ServerName:        ptr.To(testServerName),
+								CertDataSecretRef: &testCertDataSecretRef,
+								KeyDataSecretRef:  &testKeyDataSecretRef,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ClusterObservation{
+						ClusterInfo: v1alpha1.ClusterInfo{
+							ConnectionState: &v1alpha1.ConnectionState{},
+							ServerVersion:   new(string),
+							CacheInfo: &v1alpha1.ClusterCacheInfo{
+								ResourcesCount: new(int64),
+								APIsCount:      new(int64),
+							},
+						},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"TLSServerNameNotUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&argocdCluster.ClusterQuery{
+							Name:   testClusterExternalName,
+							Server: testClusterServer,
+						},
+					).Return(
+						&argocdv1alpha1.Cluster{
+							Server: testClusterServer,
+							Name:   testClusterExternalName,
+							Config: argocdv1alpha1.ClusterConfig{
+								TLSClientConfig: argocdv1alpha1.TLSClientConfig{
+									Insecure:   true,
+									ServerName: "other.example.com",
+									CertData:   []byte("redacted"),
+									KeyData:    []byte("redacted"),
+								},
+							},
+						}, nil)
+				}),
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure:          true,
+								ServerName:        ptr.To(testServerName),
+								CertDataSecretRef: &testCertDataSecretRef,
+								KeyDataSecretRef:  &testKeyDataSecretRef,
+							},
+						},
+					}),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withExternalName(testClusterExternalName),
+					withSpec(v1alpha1.ClusterParameters{
+						Server: ptr.To(testClusterServer),
+						Name:   ptr.To(testClusterExternalName),
+						Config: v1alpha1.ClusterConfig{
+							TLSClientConfig: &v1alpha1.TLSClientConfig{
+								Insecure:          true,
+								ServerName:        ptr.To(testServerName),
+								CertDataSecretRef: &testCertDataSecretRef,
+								KeyDataSecretRef:  &testKeyDataSecretRef,
+							},
+						},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ClusterObservation{
+						ClusterInfo: v1alpha1.ClusterInfo{
+							ConnectionState: &v1alpha1.ConnectionState{},
+							ServerVersion:   new(string),
+							CacheInfo: &v1alpha1.ClusterCacheInfo{
+								ResourcesCount: new(int64),
+								APIsCount:      new(int64),
+							},
+						},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
 		"GetClusterFailed": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
@@ -351,7 +578,7 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
+			e := &external{client: tc.client, lateInitialize: true}
 			o, err := e.Observe(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -367,6 +594,342 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveMetadata(t *testing.T) {
+	cases := map[string]struct {
+		labels              map[string]string
+		annotations         map[string]string
+		observedLabels      map[string]string
+		observedAnnotations map[string]string
+		want                bool
+	}{
+		"UpToDate": {
+			labels:              map[string]string{"env": "prod"},
+			annotations:         map[string]string{"team": "platform"},
+			observedLabels:      map[string]string{"env": "prod"},
+			observedAnnotations: map[string]string{"team": "platform"},
+			want:                true,
+		},
+		"UnsetEqualsEmpty": {
+			observedLabels:      map[string]string{},
+			observedAnnotations: map[string]string{},
+			want:                true,
+		},
+		"IgnoreArgoCDKeys": {
+			labels:      map[string]string{"env": "prod"},
+			annotations: map[string]string{"team": "platform"},
+			observedLabels: map[string]string{
+				"env":                            "prod",
+				"argocd.argoproj.io/secret-type": "cluster",
+			},
+			observedAnnotations: map[string]string{
+				"team":                              "platform",
+				argocdv1alpha1.AnnotationKeyRefresh: "2023-01-01T00:00:00Z",
+				"managed-by":                        "argocd.argoproj.io",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+			want: true,
+		},
+		"LabelAdded": {
+			labels:         map[string]string{"env": "prod", "region": "eu"},
+			observedLabels: map[string]string{"env": "prod"},
+			want:           false,
+		},
+		"LabelUpdated": {
+			labels:         map[string]string{"env": "prod"},
+			observedLabels: map[string]string{"env": "staging"},
+			want:           false,
+		},
+		"LabelRemoved": {
+			labels:         map[string]string{"env": "prod"},
+			observedLabels: map[string]string{"env": "prod", "region": "eu"},
+			want:           false,
+		},
+		"AnnotationAdded": {
+			annotations:         map[string]string{"team": "platform", "owner": "ops"},
+			observedAnnotations: map[string]string{"team": "platform"},
+			want:                false,
+		},
+		"AnnotationUpdated": {
+			annotations:         map[string]string{"team": "platform"},
+			observedAnnotations: map[string]string{"team": "apps"},
+			want:                false,
+		},
+		"AnnotationRemoved": {
+			annotations:         map[string]string{"team": "platform"},
+			observedAnnotations: map[string]string{"team": "platform", "owner": "ops"},
+			want:                false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.Cluster{
+					Server:      testClusterServer,
+					Name:        testClusterExternalName,
+					Labels:      tc.observedLabels,
+					Annotations: tc.observedAnnotations,
+				}, nil)
+			})
+			cr := Cluster(
+				withExternalName(testClusterExternalName),
+				withSpec(v1alpha1.ClusterParameters{
+					Server:      ptr.To(testClusterServer),
+					Name:        ptr.To(testClusterExternalName),
+					Labels:      tc.labels,
+					Annotations: tc.annotations,
+				}),
+			)
+
+			e := &external{client: client}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe(...): %v", err)
+			}
+			if !got.ResourceExists {
+				t.Errorf("Observe(...): want ResourceExists, got false")
+			}
+			if diff := cmp.Diff(tc.want, got.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...) ResourceUpToDate: -want, +got:\n%s", diff)
+			}
+			// The cluster is only ready once its metadata is consistent.
+			wantReady := v1alpha1.MetadataPending(errMetadataPending)
+			if tc.want {
+				wantReady = xpv1.Available()
+			}
+			if diff := cmp.Diff(wantReady, cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...) Ready: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegisterMetadataAtomically(t *testing.T) {
+	labels := map[string]string{"env": "prod"}
+	annotations := map[string]string{"team": "platform"}
+
+	tokenExists := false
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			if !tokenExists {
+				return kerrors.NewNotFound(corev1.Resource("secrets"), testBearerTokenRef.Name)
+			}
+			obj.(*corev1.Secret).Data = map[string][]byte{testBearerTokenRef.Key: []byte("token")}
+			return nil
+		}),
+	}
+
+	// registered is the cluster as stored by ArgoCD.
+	var registered *argocdv1alpha1.Cluster
+	mcs := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+		mcs.EXPECT().Get(context.Background(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *argocdCluster.ClusterQuery, _ ...any) (*argocdv1alpha1.Cluster, error) {
+				if registered == nil {
+					return nil, errNotFound
+				}
+				return registered, nil
+			}).AnyTimes()
+		// The cluster is registered with its labels and annotations in a
+		// single request, never updated afterwards.
+		mcs.EXPECT().Create(context.Background(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *argocdCluster.ClusterCreateRequest, _ ...any) (*argocdv1alpha1.Cluster, error) {
+				if diff := cmp.Diff("token", req.Cluster.Config.BearerToken); diff != "" {
+					t.Errorf("Create(...) bearer token: -want, +got:\n%s", diff)
+				}
+				registered = req.Cluster
+				return registered, nil
+			}).Times(1)
+	})
+
+	cr := Cluster(
+		withExternalName(testClusterExternalName),
+		withSpec(v1alpha1.ClusterParameters{
+			Server:      ptr.To(testClusterServer),
+			Name:        ptr.To(testClusterExternalName),
+			Config:      v1alpha1.ClusterConfig{BearerTokenSecretRef: &testBearerTokenRef},
+			Labels:      labels,
+			Annotations: annotations,
+		}),
+	)
+	e := &external{kube: kube, client: mcs}
+
+	observe := func() managed.ExternalObservation {
+		t.Helper()
+		o, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("Observe(...): %s", err)
+		}
+		return o
+	}
+
+	if o := observe(); o.ResourceExists {
+		t.Fatalf("Observe(...): want no cluster before it is registered")
+	}
+
+	// The registration cannot complete without the token, so nothing is
+	// written to ArgoCD and the labels are not observable.
+	if _, err := e.Create(context.Background(), cr); err == nil {
+		t.Fatalf("Create(...): want error without bearer token")
+	}
+	if o := observe(); o.ResourceExists {
+		t.Errorf("Observe(...): want no cluster after failed registration")
+	}
+
+	tokenExists = true
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create(...): %s", err)
+	}
+	if o := observe(); !o.ResourceExists || !o.ResourceUpToDate {
+		t.Errorf("Observe(...): want registered cluster up to date, got %+v", o)
+	}
+	if diff := cmp.Diff(labels, registered.Labels); diff != "" {
+		t.Errorf("registered labels: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(annotations, registered.Annotations); diff != "" {
+		t.Errorf("registered annotations: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(xpv1.Available(), cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("Observe(...) Ready: -want, +got:\n%s", diff)
+	}
+}
+
+func TestObserveNamespaces(t *testing.T) {
+	cases := map[string]struct {
+		namespaces         []string
+		observedNamespaces []string
+		want               bool
+	}{
+		"UpToDate": {
+			namespaces:         []string{"default", "kube-system"},
+			observedNamespaces: []string{"default", "kube-system"},
+			want:               true,
+		},
+		"Reordered": {
+			namespaces:         []string{"default", "kube-system"},
+			observedNamespaces: []string{"kube-system", "default"},
+			want:               true,
+		},
+		"EmptyEqualsUnset": {
+			namespaces: []string{},
+			want:       true,
+		},
+		"NamespaceAdded": {
+			namespaces:         []string{"default", "kube-system"},
+			observedNamespaces: []string{"default"},
+			want:               false,
+		},
+		"NamespaceRemoved": {
+			namespaces:         []string{"default"},
+			observedNamespaces: []string{"default", "kube-system"},
+			want:               false,
+		},
+		"NamespaceChanged": {
+			namespaces:         []string{"default"},
+			observedNamespaces: []string{"kube-system"},
+			want:               false,
+		},
+		"AllNamespaces": {
+			namespaces:         []string{},
+			observedNamespaces: []string{"default"},
+			want:               false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.Cluster{
+					Server:     testClusterServer,
+					Name:       testClusterExternalName,
+					Namespaces: tc.observedNamespaces,
+				}, nil)
+			})
+			cr := Cluster(
+				withExternalName(testClusterExternalName),
+				withSpec(v1alpha1.ClusterParameters{
+					Server:     ptr.To(testClusterServer),
+					Name:       ptr.To(testClusterExternalName),
+					Namespaces: tc.namespaces,
+				}),
+			)
+
+			e := &external{client: client}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...) ResourceUpToDate: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveProject(t *testing.T) {
+	cases := map[string]struct {
+		project         *string
+		observedProject string
+		want            bool
+	}{
+		"Unscoped": {
+			want: true,
+		},
+		"SameProject": {
+			project:         ptr.To("team-a"),
+			observedProject: "team-a",
+			want:            true,
+		},
+		"ProjectChanged": {
+			project:         ptr.To("team-b"),
+			observedProject: "team-a",
+			want:            false,
+		},
+		"ProjectAdded": {
+			project: ptr.To("team-a"),
+			want:    false,
+		},
+		"ProjectRemoved": {
+			observedProject: "team-a",
+			want:            false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.Cluster{
+					Server:  testClusterServer,
+					Name:    testClusterExternalName,
+					Project: tc.observedProject,
+				}, nil)
+			})
+			cr := Cluster(
+				withExternalName(testClusterExternalName),
+				withSpec(v1alpha1.ClusterParameters{
+					Server:  ptr.To(testClusterServer),
+					Name:    ptr.To(testClusterExternalName),
+					Project: tc.project,
+				}),
+			)
+
+			e := &external{client: client}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe(...): %v", err)
+			}
+			if !got.ResourceExists {
+				t.Errorf("Observe(...): want ResourceExists, got false")
+			}
+			if diff := cmp.Diff(tc.want, got.ResourceUpToDate); diff != "" {
+				t.Errorf("Observe(...) ResourceUpToDate: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.project, cr.Spec.ForProvider.Project); diff != "" {
+				t.Errorf("Observe(...) project must not be late initialized: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	type want struct {
 		cr     *v1alpha1.Cluster
@@ -553,6 +1116,84 @@ func TestUpdate(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"SuccessfulMetadata": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(func(_ context.Context, req *argocdCluster.ClusterUpdateRequest, _ ...any) (*argocdv1alpha1.Cluster, error) {
+						if diff := cmp.Diff(map[string]string{"env": "prod"}, req.Cluster.Labels); diff != "" {
+							t.Errorf("Update(...) labels: -want, +got:\n%s", diff)
+						}
+						if diff := cmp.Diff(map[string]string{"team": "platform"}, req.Cluster.Annotations); diff != "" {
+							t.Errorf("Update(...) annotations: -want, +got:\n%s", diff)
+						}
+						return req.Cluster, nil
+					})
+				}),
+				cr: Cluster(
+					withSpec(v1alpha1.ClusterParameters{
+						Server:      ptr.To(testClusterServer),
+						Name:        ptr.To(testClusterExternalName),
+						Labels:      map[string]string{"env": "prod"},
+						Annotations: map[string]string{"team": "platform"},
+					}),
+					withExternalName(testClusterExternalName),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withSpec(v1alpha1.ClusterParameters{
+						Server:      ptr.To(testClusterServer),
+						Name:        ptr.To(testClusterExternalName),
+						Labels:      map[string]string{"env": "prod"},
+						Annotations: map[string]string{"team": "platform"},
+					}),
+					withExternalName(testClusterExternalName),
+				),
+				result: managed.ExternalUpdate{},
+			},
+		},
+		"SuccessfulProjectReassignment": {
+			args: args{
+				// Clusters are keyed by server, so the project is changed in
+				// place. Neither Delete nor Create are expected.
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(func(_ context.Context, req *argocdCluster.ClusterUpdateRequest, _ ...any) (*argocdv1alpha1.Cluster, error) {
+						if diff := cmp.Diff(testClusterServer, req.Cluster.Server); diff != "" {
+							t.Errorf("Update(...) server: -want, +got:\n%s", diff)
+						}
+						if diff := cmp.Diff("team-b", req.Cluster.Project); diff != "" {
+							t.Errorf("Update(...) project: -want, +got:\n%s", diff)
+						}
+						return req.Cluster, nil
+					})
+				}),
+				cr: Cluster(
+					withSpec(v1alpha1.ClusterParameters{
+						Server:  ptr.To(testClusterServer),
+						Name:    ptr.To(testClusterExternalName),
+						Project: ptr.To("team-b"),
+					}),
+					withExternalName(testClusterExternalName),
+				),
+			},
+			want: want{
+				cr: Cluster(
+					withSpec(v1alpha1.ClusterParameters{
+						Server:  ptr.To(testClusterServer),
+						Name:    ptr.To(testClusterExternalName),
+						Project: ptr.To("team-b"),
+					}),
+					withExternalName(testClusterExternalName),
+				),
+				result: managed.ExternalUpdate{},
+			},
+		},
 		"UpdateClusterFailed": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
@@ -603,6 +1244,74 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestBearerTokenRotation(t *testing.T) {
+	token := "old-token"
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{testBearerTokenRef.Key: []byte(token)}
+			return nil
+		}),
+	}
+
+	// ArgoCD redacts the bearer token, so the observed cluster never changes.
+	mcs := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+		mcs.EXPECT().Get(context.Background(), gomock.Any()).Return(&argocdv1alpha1.Cluster{
+			Server: testClusterServer,
+			Name:   testClusterExternalName,
+		}, nil).AnyTimes()
+		mcs.EXPECT().Update(context.Background(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *argocdCluster.ClusterUpdateRequest, _ ...any) (*argocdv1alpha1.Cluster, error) {
+				if diff := cmp.Diff("new-token", req.Cluster.Config.BearerToken); diff != "" {
+					t.Errorf("Update(...) bearer token: -want, +got:\n%s", diff)
+				}
+				return req.Cluster, nil
+			}).Times(1)
+	})
+
+	cr := Cluster(
+		withExternalName(testClusterExternalName),
+		withSpec(v1alpha1.ClusterParameters{
+			Server: ptr.To(testClusterServer),
+			Name:   ptr.To(testClusterExternalName),
+			Config: v1alpha1.ClusterConfig{
+				BearerTokenSecretRef: &testBearerTokenRef,
+			},
+		}),
+	)
+	e := &external{kube: kube, client: mcs}
+
+	observe := func(wantUpToDate bool) {
+		t.Helper()
+		o, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("Observe(...): %s", err)
+		}
+		if o.ResourceUpToDate != wantUpToDate {
+			t.Errorf("Observe(...): want ResourceUpToDate %t, got %t", wantUpToDate, o.ResourceUpToDate)
+		}
+	}
+
+	// The token the cluster was created with is stored by the first Observe,
+	// since the status set by Create is not persisted.
+	observe(true)
+	hash, err := e.getBearerTokenHash(context.Background(), &testBearerTokenRef)
+	if err != nil {
+		t.Fatalf("getBearerTokenHash(...): %s", err)
+	}
+	if diff := cmp.Diff(hash, cr.Status.AtProvider.BearerTokenHash); diff != "" {
+		t.Errorf("Observe(...) bearer token hash: -want, +got:\n%s", diff)
+	}
+
+	// Rotating the token triggers exactly one Update.
+	token = "new-token"
+	observe(false)
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): %s", err)
+	}
+	observe(true)
+	observe(true)
+}
+
 func TestDelete(t *testing.T) {
 	type want struct {
 		cr  *v1alpha1.Cluster
@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpgkeys
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/gpgkey"
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/gpgkeys/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	gpgkeysclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/gpgkeys"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/options"
+	"github.com/crossplane-contrib/provider-argocd/pkg/jitter"
+	"github.com/crossplane-contrib/provider-argocd/pkg/metrics"
+	"github.com/crossplane-contrib/provider-argocd/pkg/shard"
+	"github.com/crossplane-contrib/provider-argocd/pkg/tracing"
+)
+
+const (
+	errNotGPGKey    = "managed resource is not a Argocd GPG key custom resource"
+	errListFailed   = "cannot list Argocd GPG keys"
+	errCreateFailed = "cannot create Argocd GPG key"
+	errDeleteFailed = "cannot delete Argocd GPG key"
+	errFmtKeyCount  = "public key must contain exactly one key, found %d"
+)
+
+// SetupGPGKey adds a controller that reconciles GPG keys.
+func SetupGPGKey(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.GPGKeyKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		Watches(&source.Kind{Type: &v1alpha1.GPGKey{}}, jitter.NewEventHandler(o.Jitter.For(v1alpha1.GPGKeyKind)), builder.WithPredicates(shard.NewPredicate(o.ShardSelector))).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.GPGKeyGroupVersionKind),
+			managed.WithExternalConnecter(tracing.NewConnecter(v1alpha1.GPGKeyKind, metrics.NewDriftConnecter(v1alpha1.GPGKeyKind, &connector{kube: mgr.GetClient(), pool: o.Pool, newArgocdClientFn: gpgkeysclient.NewGPGKeyServiceClient}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type connector struct {
+	kube              client.Client
+	pool              *clients.Pool
+	newArgocdClientFn func(c *clients.PooledClient) (gpgkey.GPGKeyServiceClient, error)
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.GPGKey)
+	if !ok {
+		return nil, errors.New(errNotGPGKey)
+	}
+	pc, err := c.pool.Connect(ctx, c.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	argocdClient, err := c.newArgocdClientFn(pc)
+	if err != nil {
+		return nil, err
+	}
+	return &external{client: argocdClient}, nil
+}
+
+type external struct {
+	client gpgkeysclient.ServiceClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.GPGKey)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotGPGKey)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	list, err := e.client.List(ctx, &gpgkey.GnuPGPublicKeyQuery{})
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(clients.HandleMaintenance(cr, err), errListFailed)
+	}
+	var observed *argocdv1alpha1.GnuPGPublicKey
+	for i := range list.Items {
+		if list.Items[i].KeyID == meta.GetExternalName(cr) {
+			observed = &list.Items[i]
+			break
+		}
+	}
+	if observed == nil {
+		return managed.ExternalObservation{}, nil
+	}
+
+	cr.Status.AtProvider = generateGPGKeyObservation(observed)
+	cr.Status.SetConditions(xpv1.Available())
+
+	// The public key is immutable, so a key that exists is up to date.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.GPGKey)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotGPGKey)
+	}
+
+	resp, err := e.client.Create(ctx, &gpgkey.GnuPGPublicKeyCreateRequest{
+		Publickey: &argocdv1alpha1.GnuPGPublicKey{KeyData: cr.Spec.ForProvider.PublicKey},
+	})
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	// ArgoCD skips keys that it already knows. Such a key is adopted.
+	keyIDs := resp.Skipped
+	if resp.Created != nil {
+		for _, k := range resp.Created.Items {
+			keyIDs = append(keyIDs, k.KeyID)
+		}
+	}
+	if len(keyIDs) != 1 {
+		return managed.ExternalCreation{}, errors.Errorf(errFmtKeyCount, len(keyIDs))
+	}
+
+	meta.SetExternalName(cr, keyIDs[0])
+
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+	}, nil
+}
+
+// Update does nothing, since the public key cannot be changed.
+func (e *external) Update(_ context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.GPGKey); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotGPGKey)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.GPGKey)
+	if !ok {
+		return errors.New(errNotGPGKey)
+	}
+
+	_, err := e.client.Delete(ctx, &gpgkey.GnuPGPublicKeyQuery{KeyID: meta.GetExternalName(cr)})
+	return errors.Wrap(err, errDeleteFailed)
+}
+
+func generateGPGKeyObservation(k *argocdv1alpha1.GnuPGPublicKey) v1alpha1.GPGKeyObservation {
+	return v1alpha1.GPGKeyObservation{
+		KeyID:       k.KeyID,
+		Fingerprint: k.Fingerprint,
+		Owner:       k.Owner,
+		Trust:       k.Trust,
+		SubType:     k.SubType,
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpgkeys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/gpgkey"
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/gpgkeys/v1alpha1"
+	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/gpgkeys"
+)
+
+var (
+	errBoom        = errors.New("boom")
+	testKeyID      = "4AEE18F83AFDEB23"
+	testOtherKeyID = "7A12AD5B8E34C1B6"
+	testPublicKey  = "-----BEGIN PGP PUBLIC KEY BLOCK-----\n...\n-----END PGP PUBLIC KEY BLOCK-----\n"
+	testKey        = argocdv1alpha1.GnuPGPublicKey{
+		KeyID:       testKeyID,
+		Fingerprint: "5DE3E0509C47EA3CF04A42D34AEE18F83AFDEB23",
+		Owner:       "GitHub (web-flow commit signing) <noreply@github.com>",
+		Trust:       "unknown",
+		SubType:     "rsa2048",
+	}
+)
+
+type mockModifier func(*mockclient.MockServiceClient)
+
+func withMockClient(t *testing.T, mod mockModifier) *mockclient.MockServiceClient {
+	ctrl := gomock.NewController(t)
+	mock := mockclient.NewMockServiceClient(ctrl)
+	mod(mock)
+	return mock
+}
+
+func GPGKey(m ...func(*v1alpha1.GPGKey)) *v1alpha1.GPGKey {
+	cr := &v1alpha1.GPGKey{Spec: v1alpha1.GPGKeySpec{ForProvider: v1alpha1.GPGKeyParameters{PublicKey: testPublicKey}}}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func withExternalName(n string) func(*v1alpha1.GPGKey) {
+	return func(cr *v1alpha1.GPGKey) { meta.SetExternalName(cr, n) }
+}
+
+func withObservation(o v1alpha1.GPGKeyObservation) func(*v1alpha1.GPGKey) {
+	return func(cr *v1alpha1.GPGKey) { cr.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) func(*v1alpha1.GPGKey) {
+	return func(cr *v1alpha1.GPGKey) { cr.Status.SetConditions(c...) }
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.GPGKey
+		result managed.ExternalObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		client mockModifier
+		cr     *v1alpha1.GPGKey
+		want   want
+	}{
+		"NoExternalName": {
+			client: func(_ *mockclient.MockServiceClient) {},
+			cr:     GPGKey(),
+			want: want{
+				cr:     GPGKey(),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NotFound": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), &gpgkey.GnuPGPublicKeyQuery{}).
+					Return(&argocdv1alpha1.GnuPGPublicKeyList{Items: []argocdv1alpha1.GnuPGPublicKey{{KeyID: testOtherKeyID}}}, nil)
+			},
+			cr: GPGKey(withExternalName(testKeyID)),
+			want: want{
+				cr:     GPGKey(withExternalName(testKeyID)),
+				result: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ListFailed": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), &gpgkey.GnuPGPublicKeyQuery{}).Return(nil, errBoom)
+			},
+			cr: GPGKey(withExternalName(testKeyID)),
+			want: want{
+				cr:  GPGKey(withExternalName(testKeyID)),
+				err: errors.Wrap(errBoom, errListFailed),
+			},
+		},
+		"Found": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().List(context.Background(), &gpgkey.GnuPGPublicKeyQuery{}).
+					Return(&argocdv1alpha1.GnuPGPublicKeyList{Items: []argocdv1alpha1.GnuPGPublicKey{{KeyID: testOtherKeyID}, testKey}}, nil)
+			},
+			cr: GPGKey(withExternalName(testKeyID)),
+			want: want{
+				cr: GPGKey(
+					withExternalName(testKeyID),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.GPGKeyObservation{
+						KeyID:       testKey.KeyID,
+						Fingerprint: testKey.Fingerprint,
+						Owner:       testKey.Owner,
+						Trust:       testKey.Trust,
+						SubType:     testKey.SubType,
+					}),
+				),
+				result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: withMockClient(t, tc.client)}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want cr, +got cr:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.GPGKey
+		result managed.ExternalCreation
+		err    error
+	}
+
+	expectCreate := func(resp *gpgkey.GnuPGPublicKeyCreateResponse, err error) mockModifier {
+		return func(mcs *mockclient.MockServiceClient) {
+			mcs.EXPECT().Create(context.Background(), &gpgkey.GnuPGPublicKeyCreateRequest{
+				Publickey: &argocdv1alpha1.GnuPGPublicKey{KeyData: testPublicKey},
+			}).Return(resp, err)
+		}
+	}
+
+	cases := map[string]struct {
+		client mockModifier
+		want   want
+	}{
+		"Created": {
+			client: expectCreate(&gpgkey.GnuPGPublicKeyCreateResponse{
+				Created: &argocdv1alpha1.GnuPGPublicKeyList{Items: []argocdv1alpha1.GnuPGPublicKey{testKey}},
+			}, nil),
+			want: want{
+				cr:     GPGKey(withExternalName(testKeyID)),
+				result: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+		"AlreadyExists": {
+			client: expectCreate(&gpgkey.GnuPGPublicKeyCreateResponse{
+				Created: &argocdv1alpha1.GnuPGPublicKeyList{},
+				Skipped: []string{testKeyID},
+			}, nil),
+			want: want{
+				cr:     GPGKey(withExternalName(testKeyID)),
+				result: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+		"MultipleKeys": {
+			client: expectCreate(&gpgkey.GnuPGPublicKeyCreateResponse{
+				Created: &argocdv1alpha1.GnuPGPublicKeyList{Items: []argocdv1alpha1.GnuPGPublicKey{testKey, {KeyID: testOtherKeyID}}},
+			}, nil),
+			want: want{
+				cr:  GPGKey(),
+				err: errors.Errorf(errFmtKeyCount, 2),
+			},
+		},
+		"CreateFailed": {
+			client: expectCreate(nil, errBoom),
+			want: want{
+				cr:  GPGKey(),
+				err: errors.Wrap(errBoom, errCreateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := GPGKey()
+			e := &external{client: withMockClient(t, tc.client)}
+			got, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, cr); diff != "" {
+				t.Errorf("Create(...): -want cr, +got cr:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		client mockModifier
+		want   error
+	}{
+		"Successful": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().Delete(context.Background(), &gpgkey.GnuPGPublicKeyQuery{KeyID: testKeyID}).
+					Return(&gpgkey.GnuPGPublicKeyResponse{}, nil)
+			},
+		},
+		"DeleteFailed": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().Delete(context.Background(), &gpgkey.GnuPGPublicKeyQuery{KeyID: testKeyID}).Return(nil, errBoom)
+			},
+			want: errors.Wrap(errBoom, errDeleteFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: withMockClient(t, tc.client)}
+			err := e.Delete(context.Background(), GPGKey(withExternalName(testKeyID)))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
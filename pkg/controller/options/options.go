@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains the options shared by the argocd controllers.
+package options
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	"github.com/crossplane-contrib/provider-argocd/pkg/jitter"
+)
+
+// Options configure the argocd controllers. They are set up once at startup
+// and passed to the Setup function of each controller.
+type Options struct {
+	// Pool shares the argocd clients of each ProviderConfig between the
+	// controllers.
+	Pool *clients.Pool
+
+	// Jitter delays the first reconcile of new managed resources.
+	Jitter jitter.Options
+
+	// LateInitialize copies the observed values of fields that are unset in
+	// the spec of managed resources into the spec, unless a resource
+	// overrides it, see clients.ShouldLateInitialize.
+	LateInitialize bool
+
+	// ShardSelector selects the managed resources reconciled by this
+	// provider instance. A nil selector selects all resources.
+	ShardSelector labels.Selector
+
+	// Namespace is the namespace ArgoCD is installed in. It is the default
+	// namespace of Applications and the namespace AppProjects are created
+	// in.
+	Namespace string
+}
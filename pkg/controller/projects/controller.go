@@ -18,15 +18,22 @@ package projects
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
-	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -36,8 +43,15 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
+	"github.com/crossplane-contrib/provider-argocd/pkg/connection"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/options"
+	"github.com/crossplane-contrib/provider-argocd/pkg/jitter"
+	"github.com/crossplane-contrib/provider-argocd/pkg/metrics"
+	"github.com/crossplane-contrib/provider-argocd/pkg/shard"
+	"github.com/crossplane-contrib/provider-argocd/pkg/tracing"
 )
 
 const (
@@ -47,26 +61,113 @@ const (
 	errCreateFailed     = "cannot create Argocd Project"
 	errUpdateFailed     = "cannot update Argocd Project"
 	errDeleteFailed     = "cannot delete Argocd Project"
+	errCreateToken      = "cannot create Argocd Project token"
+	errRevokeToken      = "cannot revoke Argocd Project token"
+
+	syncWindowKindAllow = "allow"
+	syncWindowKindDeny  = "deny"
+	sourceRepoWildcard  = "*"
+
+	errFmtSyncWindowTimeZone = "sync window %d has an invalid timeZone %q"
+
+	errResolveReferences = "cannot resolve references"
+	errUpdateManaged     = "cannot update managed resource"
+
+	reasonCannotCreateToken event.Reason = "CannotCreateToken"
 )
 
 // SetupProject adds a controller that reconciles projects.
-func SetupProject(mgr ctrl.Manager, l logging.Logger) error {
+func SetupProject(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.ProjectKind)
 
+	cps := []managed.ConnectionPublisher{connection.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.Project{}).
+		Watches(&source.Kind{Type: &v1alpha1.Project{}}, jitter.NewEventHandler(o.Jitter.For(v1alpha1.ProjectKind)), builder.WithPredicates(shard.NewPredicate(o.ShardSelector))).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(referencingProjects(mgr.GetClient(), o.ShardSelector))).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.ProjectGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: projects.NewProjectServiceClient}),
+			managed.WithExternalConnecter(tracing.NewConnecter(v1alpha1.ProjectKind, metrics.NewDriftConnecter(v1alpha1.ProjectKind, &connector{kube: mgr.GetClient(), pool: o.Pool, lateInitialize: o.LateInitialize, newArgocdClientFn: projects.NewProjectServiceClient, namespace: o.Namespace, recorder: recorder}))),
+			managed.WithReferenceResolver(&referenceResolver{kube: mgr.GetClient()}),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(recorder),
+			managed.WithConnectionPublishers(cps...)))
+}
+
+// referenceResolver resolves the references of a Project like the
+// managed.APISimpleReferenceResolver. A sourceRepos wildcard is set aside
+// while resolving, so that it neither prevents sourceReposRefs from being
+// resolved nor is replaced by their resolved values.
+type referenceResolver struct {
+	kube client.Client
+}
+
+func (r *referenceResolver) ResolveReferences(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Project)
+	if !ok {
+		return errors.New(errNotProject)
+	}
+
+	existing := cr.DeepCopy()
+	wildcard := containsString(cr.Spec.ForProvider.SourceRepos, sourceRepoWildcard)
+	if wildcard {
+		cr.Spec.ForProvider.SourceRepos = withoutString(cr.Spec.ForProvider.SourceRepos, sourceRepoWildcard)
+	}
+	err := cr.ResolveReferences(ctx, r.kube)
+	if wildcard {
+		cr.Spec.ForProvider.SourceRepos = append([]string{sourceRepoWildcard}, cr.Spec.ForProvider.SourceRepos...)
+	}
+	if err != nil {
+		return errors.Wrap(err, errResolveReferences)
+	}
+
+	if cmp.Equal(existing, cr) {
+		return nil
+	}
+	return errors.Wrap(r.kube.Update(ctx, cr), errUpdateManaged)
+}
+
+func withoutString(l []string, s string) []string {
+	var out []string
+	for _, v := range l {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// withoutDuplicates returns the strings of l in the order of their first
+// occurrence.
+func withoutDuplicates(l []string) []string {
+	out := make([]string, 0, len(l))
+	for _, v := range l {
+		if !containsString(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func containsString(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
 
 type connector struct {
 	kube              client.Client
-	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) project.ProjectServiceClient
+	pool              *clients.Pool
+	newArgocdClientFn func(c *clients.PooledClient) (project.ProjectServiceClient, error)
+	namespace         string
+	recorder          event.Recorder
+	lateInitialize    bool
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -74,16 +175,23 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if !ok {
 		return nil, errors.New(errNotProject)
 	}
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	pc, err := c.pool.Connect(ctx, c.kube, cr)
 	if err != nil {
 		return nil, err
 	}
-	return &external{kube: c.kube, client: c.newArgocdClientFn(cfg)}, nil
+	argocdClient, err := c.newArgocdClientFn(pc)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.kube, client: argocdClient, lateInitialize: c.lateInitialize, namespace: c.namespace, recorder: c.recorder}, nil
 }
 
 type external struct {
-	kube   client.Client
-	client projects.ProjectServiceClient
+	kube           client.Client
+	client         projects.ProjectServiceClient
+	namespace      string
+	recorder       event.Recorder
+	lateInitialize bool
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -107,18 +215,36 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, nil
 	}
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errGetFailed)
+		return managed.ExternalObservation{}, errors.Wrap(clients.HandleMaintenance(cr, err), errGetFailed)
 	}
 
 	current := cr.Spec.ForProvider.DeepCopy()
-	lateInitializeProject(&cr.Spec.ForProvider, &project.Spec)
+	// Once the Project was observed, an unset field was removed by the user
+	// and must be cleared instead of being initialized with the stale value.
+	// A partially managed Project would take over the fields of the other
+	// owner otherwise.
+	if !wasObserved(cr) && clients.ShouldLateInitialize(cr, e.lateInitialize) && managedFields(&cr.Spec.ForProvider) == nil {
+		lateInitializeProject(&cr.Spec.ForProvider, &project.Spec)
+	}
 
 	cr.Status.AtProvider = generateProjectObservation(project)
+	cr.Status.AtProvider.JWTTokenIDsByName = generateJWTTokenIDsByName(&cr.Spec.ForProvider, project)
 	cr.Status.SetConditions(xpv1.Available())
+	setProjectWarnings(cr, project)
+
+	// Invalid policies and unresolvable whitelists are reported by Update,
+	// not here, so that they never prevent deleting the Project.
+	desired := cr
+	if n, err := withNormalizedPolicies(cr); err == nil {
+		desired = n
+	}
+	if r, err := withResolvedWhitelists(ctx, e.kube, desired); err == nil {
+		desired = r
+	}
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        isProjectUpToDate(&cr.Spec.ForProvider, project),
+		ResourceUpToDate:        isProjectUpToDate(&desired.Spec.ForProvider, project),
 		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
 	}, nil
 }
@@ -129,7 +255,18 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotProject)
 	}
 
-	projCreateRequest := generateCreateProjectOptions(cr)
+	desired, err := withNormalizedPolicies(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	desired, err = withResolvedWhitelists(ctx, e.kube, desired)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := validateSyncWindows(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	projCreateRequest := generateCreateProjectOptions(desired, e.namespace)
 
 	resp, err := e.client.Create(ctx, projCreateRequest)
 	if err != nil {
@@ -148,6 +285,17 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotProject)
 	}
+	desired, err := withNormalizedPolicies(cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	desired, err = withResolvedWhitelists(ctx, e.kube, desired)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := validateSyncWindows(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 	projQuery := project.ProjectQuery{
 		Name: meta.GetExternalName(cr),
 	}
@@ -157,11 +305,69 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
 	}
 
-	projUpdateRequest := generateUpdateProjectOptions(cr, proj)
+	projUpdateRequest := generateUpdateProjectOptions(desired, proj)
+
+	if _, err := e.client.Update(ctx, projUpdateRequest); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
 
-	_, err = e.client.Update(ctx, projUpdateRequest)
+	// The project itself is updated at this point, so a token that cannot
+	// be created is reported as pending until the next reconcile creates it.
+	conn, err := e.createNamedTokens(ctx, cr, proj)
+	if err != nil {
+		cr.Status.SetConditions(v1alpha1.TokensPending(errors.Wrap(err, errCreateToken).Error()))
+	} else if cr.Status.GetCondition(v1alpha1.TypeTokens).Status == corev1.ConditionFalse {
+		cr.Status.SetConditions(v1alpha1.TokensCreated())
+	}
+	if err != nil && len(conn) == 0 {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errCreateToken)
+	}
+	// Created tokens can only be published now, since ArgoCD never returns
+	// them again, and connection details are not published if Update fails.
+	// So they are returned, and the failure is reported as a warning event.
+	if err != nil {
+		e.recorder.Event(cr, event.Warning(reasonCannotCreateToken, errors.Wrap(err, errCreateToken)))
+	}
+	return managed.ExternalUpdate{ConnectionDetails: conn}, nil
+}
 
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+// createNamedTokens creates the named tokens of the project roles that do not
+// exist yet or expired and returns them as connection details. The token name
+// is used as token ID, so a token that exists is found again even if the
+// status of the Project was not persisted after creating it. Expired tokens
+// were removed by the preceding update, so their IDs can be used again.
+func (e *external) createNamedTokens(ctx context.Context, cr *v1alpha1.Project, current *argocdv1alpha1.AppProject) (managed.ConnectionDetails, error) {
+	if !managedFields(&cr.Spec.ForProvider).has(v1alpha1.ProjectFieldRoles) {
+		return nil, nil
+	}
+	var conn managed.ConnectionDetails
+	for _, r := range cr.Spec.ForProvider.Roles {
+		for _, t := range r.JWTTokens {
+			for _, nt := range namedTokens(r.Name, t) {
+				if _, ok := findJWTToken(current, r.Name, nt.id); ok {
+					continue
+				}
+				req := &project.ProjectTokenCreateRequest{
+					Project:     meta.GetExternalName(cr),
+					Role:        r.Name,
+					Id:          nt.id,
+					Description: nt.id,
+				}
+				if t.ExpiresIn != nil {
+					req.ExpiresIn = int64(t.ExpiresIn.Duration.Seconds())
+				}
+				resp, err := e.client.CreateToken(ctx, req)
+				if err != nil {
+					return conn, errors.Wrapf(err, "role %s, token %s", r.Name, nt.id)
+				}
+				if conn == nil {
+					conn = managed.ConnectionDetails{}
+				}
+				conn[nt.key] = []byte(resp.Token)
+			}
+		}
+	}
+	return conn, nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -169,15 +375,76 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	if !ok {
 		return errors.New(errNotProject)
 	}
+	deps, err := dependents(ctx, e.kube, cr)
+	if err != nil {
+		return err
+	}
+	if err := errDependents(deps); err != nil {
+		return err
+	}
+	if err := e.revokeNamedTokens(ctx, cr); err != nil {
+		return err
+	}
 	projQuery := project.ProjectQuery{
 		Name: meta.GetExternalName(cr),
 	}
 
-	_, err := e.client.Delete(ctx, &projQuery)
+	_, err = e.client.Delete(ctx, &projQuery)
 
 	return errors.Wrap(err, errDeleteFailed)
 }
 
+// revokeNamedTokens revokes the named tokens the provider created for the
+// roles of the project. Deleting the project invalidates its tokens as well,
+// but a project of the same name created later would accept them again.
+// The connection secret holding the tokens is deleted once the project is
+// gone.
+func (e *external) revokeNamedTokens(ctx context.Context, cr *v1alpha1.Project) error {
+	var named bool
+	for _, r := range cr.Spec.ForProvider.Roles {
+		for _, t := range r.JWTTokens {
+			named = named || len(namedTokens(r.Name, t)) > 0
+		}
+	}
+	if !named {
+		return nil
+	}
+
+	current, err := e.client.Get(ctx, &project.ProjectQuery{Name: meta.GetExternalName(cr)})
+	if projects.IsErrorProjectNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetFailed)
+	}
+	for _, r := range cr.Spec.ForProvider.Roles {
+		for _, t := range r.JWTTokens {
+			for _, nt := range namedTokens(r.Name, t) {
+				existing, ok := findJWTToken(current, r.Name, nt.id)
+				if !ok {
+					continue
+				}
+				req := &project.ProjectTokenDeleteRequest{
+					Project: meta.GetExternalName(cr),
+					Role:    r.Name,
+					Id:      existing.ID,
+					Iat:     existing.IssuedAt,
+				}
+				if _, err := e.client.DeleteToken(ctx, req); err != nil {
+					return errors.Wrap(errors.Wrapf(err, "role %s, token %s", r.Name, nt.id), errRevokeToken)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// wasObserved returns true if the Project was available at its last
+// observation.
+func wasObserved(cr *v1alpha1.Project) bool {
+	return cr.Status.GetCondition(xpv1.TypeReady).Reason == xpv1.ReasonAvailable
+}
+
 func lateInitializeProject(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppProjectSpec) { // nolint:gocyclo // checking all parameters can't be reduced
 	if r == nil {
 		return
@@ -203,31 +470,9 @@ func lateInitializeProject(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppP
 		p.Description = &r.Description
 	}
 
-	if p.Roles == nil && r.Roles != nil {
-		p.Roles = make([]v1alpha1.ProjectRole, len(r.Roles))
-		for i, res := range r.Roles {
-			res := res // FIX go linter exportloopref
-			jwtTokens := make([]v1alpha1.JWTToken, len(res.JWTTokens))
-			for j, t := range res.JWTTokens {
-				t := t // FIX go linter exportloopref
-				jwtTokens[j] = v1alpha1.JWTToken{
-					IssuedAt:  t.IssuedAt,
-					ExpiresAt: &t.ExpiresAt,
-					ID:        &t.ID,
-				}
-			}
-
-			p.Roles[i] = v1alpha1.ProjectRole{
-				Name:        res.Name,
-				Description: &res.Description,
-				Policies:    res.Policies,
-				JWTTokens:   jwtTokens,
-				Groups:      res.Groups,
-			}
-		}
-	}
-
-	if p.ClusterResourceWhitelist == nil {
+	// Resources of referenced ConfigMaps must not become inline resources,
+	// or removing them from the ConfigMap would have no effect.
+	if p.ClusterResourceWhitelist == nil && p.ClusterResourceWhitelistFrom == nil {
 		p.ClusterResourceWhitelist = r.ClusterResourceWhitelist
 	}
 
@@ -235,6 +480,9 @@ func lateInitializeProject(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppP
 		p.NamespaceResourceBlacklist = r.NamespaceResourceBlacklist
 	}
 
+	if p.OrphanedResources != nil && p.OrphanedResources.Warn == nil && r.OrphanedResources != nil {
+		p.OrphanedResources.Warn = r.OrphanedResources.Warn
+	}
 	if p.OrphanedResources == nil && r.OrphanedResources != nil {
 		p.OrphanedResources = &v1alpha1.OrphanedResourcesMonitorSettings{
 			Warn: r.OrphanedResources.Warn,
@@ -253,23 +501,7 @@ func lateInitializeProject(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppP
 		}
 	}
 
-	if p.SyncWindows == nil && r.SyncWindows != nil {
-		p.SyncWindows = make([]v1alpha1.SyncWindow, len(r.SyncWindows))
-
-		for i, res := range r.SyncWindows {
-			p.SyncWindows[i] = v1alpha1.SyncWindow{
-				Kind:         &res.Kind,
-				Schedule:     &res.Schedule,
-				Duration:     &res.Duration,
-				Applications: res.Applications,
-				Namespaces:   res.Namespaces,
-				Clusters:     res.Clusters,
-				ManualSync:   &res.ManualSync,
-			}
-		}
-	}
-
-	if p.NamespaceResourceWhitelist == nil {
+	if p.NamespaceResourceWhitelist == nil && p.NamespaceResourceWhitelistFrom == nil {
 		p.NamespaceResourceWhitelist = r.NamespaceResourceWhitelist
 	}
 	if p.SignatureKeys == nil && r.SignatureKeys != nil {
@@ -285,6 +517,74 @@ func lateInitializeProject(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppP
 	}
 }
 
+// generateJWTTokenIDsByName maps the named tokens of the desired roles that
+// exist in the AppProject to their token IDs.
+func generateJWTTokenIDsByName(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppProject) map[string]string {
+	var ids map[string]string
+	for _, role := range p.Roles {
+		for _, t := range role.JWTTokens {
+			for _, nt := range namedTokens(role.Name, t) {
+				existing, ok := findJWTToken(r, role.Name, nt.id)
+				if !ok {
+					continue
+				}
+				if ids == nil {
+					ids = map[string]string{}
+				}
+				ids[role.Name+"/"+nt.id] = existing.ID
+			}
+		}
+	}
+	return ids
+}
+
+// namedToken is a token created by the provider for a named token.
+type namedToken struct {
+	// id is the token ID.
+	id string
+	// key is the connection detail key the token is published as.
+	key string
+}
+
+// namedTokens returns the tokens the provider keeps for the supplied token of
+// the given role. An unnamed token has none.
+func namedTokens(role string, t v1alpha1.JWTToken) []namedToken {
+	switch {
+	case t.Name == nil:
+		return nil
+	case t.Count == nil:
+		return []namedToken{{id: *t.Name, key: role + "." + *t.Name}}
+	}
+	res := make([]namedToken, 0, *t.Count)
+	for i := 0; i < int(*t.Count); i++ {
+		res = append(res, namedToken{
+			id:  fmt.Sprintf("%s-%d", *t.Name, i),
+			key: fmt.Sprintf("%s.%s.%d", role, *t.Name, i),
+		})
+	}
+	return res
+}
+
+// findJWTToken returns the token of the given role with the ID of a named
+// token, unless it expired.
+func findJWTToken(r *argocdv1alpha1.AppProject, role, id string) (argocdv1alpha1.JWTToken, bool) {
+	for _, pr := range r.Spec.Roles {
+		if pr.Name != role {
+			continue
+		}
+		for _, t := range pr.JWTTokens {
+			if t.ID == id && !isExpired(t) {
+				return t, true
+			}
+		}
+	}
+	return argocdv1alpha1.JWTToken{}, false
+}
+
+func isExpired(t argocdv1alpha1.JWTToken) bool {
+	return t.ExpiresAt != 0 && t.ExpiresAt <= time.Now().Unix()
+}
+
 func generateProjectObservation(r *argocdv1alpha1.AppProject) v1alpha1.ProjectObservation {
 	if r == nil {
 		return v1alpha1.ProjectObservation{}
@@ -313,13 +613,93 @@ func generateProjectObservation(r *argocdv1alpha1.AppProject) v1alpha1.ProjectOb
 	return o
 }
 
-func generateCreateProjectOptions(p *v1alpha1.Project) *project.ProjectCreateRequest {
+// setProjectWarnings reports non-fatal findings about the observed AppProject
+// as a Warning condition. ArgoCD accepts such projects, so the resource stays
+// available.
+func setProjectWarnings(cr *v1alpha1.Project, r *argocdv1alpha1.AppProject) {
+	warnings := generateProjectWarnings(r)
+	if len(warnings) > 0 {
+		cr.Status.SetConditions(apisv1alpha1.ValidationWarning(strings.Join(warnings, "; ")))
+		return
+	}
+	if cr.Status.GetCondition(apisv1alpha1.TypeWarning).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(apisv1alpha1.NoWarnings())
+	}
+}
+
+// generateProjectWarnings inspects the sync windows of an AppProject. Windows
+// that are defined twice, and allow windows that are shadowed by a deny window
+// with the same schedule for the same targets, are reported.
+func generateProjectWarnings(r *argocdv1alpha1.AppProject) []string {
+	var warnings []string
+	windows := r.Spec.SyncWindows
+	for i := range windows {
+		for j := i + 1; j < len(windows); j++ {
+			a, b := windows[i], windows[j]
+			if a == nil || b == nil || a.Schedule != b.Schedule || !isSyncWindowTargetOverlapping(a, b) {
+				continue
+			}
+			if a.Kind == b.Kind && a.Duration == b.Duration {
+				warnings = append(warnings, fmt.Sprintf("sync windows %d and %d are duplicates", i, j))
+				continue
+			}
+			// The windows may be listed in any order.
+			allow, deny := a, b
+			if allow.Kind != syncWindowKindAllow {
+				allow, deny = b, a
+			}
+			if allow.Kind == syncWindowKindAllow && deny.Kind == syncWindowKindDeny {
+				warnings = append(warnings, fmt.Sprintf("sync windows %d and %d overlap: %s window with schedule %q is shadowed by the %s window", i, j, allow.Kind, allow.Schedule, deny.Kind))
+			}
+		}
+	}
+	return warnings
+}
+
+// isSyncWindowTargetOverlapping returns true if both windows apply to at least
+// one common application, namespace or cluster.
+func isSyncWindowTargetOverlapping(a, b *argocdv1alpha1.SyncWindow) bool {
+	return isPatternListOverlapping(a.Applications, b.Applications) ||
+		isPatternListOverlapping(a.Namespaces, b.Namespaces) ||
+		isPatternListOverlapping(a.Clusters, b.Clusters)
+}
+
+// validateSyncWindows returns an error if the time zone of a sync window is
+// unknown. ArgoCD accepts any time zone, but then never opens the window.
+func validateSyncWindows(p *v1alpha1.ProjectParameters) error {
+	for i, w := range p.SyncWindows {
+		if w.TimeZone == nil {
+			continue
+		}
+		if _, err := time.LoadLocation(*w.TimeZone); err != nil {
+			return errors.Wrapf(err, errFmtSyncWindowTimeZone, i, *w.TimeZone)
+		}
+	}
+	return nil
+}
+
+func isPatternListOverlapping(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y || x == "*" || y == "*" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generateCreateProjectOptions returns the request creating the supplied
+// Project in the supplied namespace ArgoCD is installed in. ArgoCD always
+// creates AppProjects in its own namespace, and rejects any other one.
+func generateCreateProjectOptions(p *v1alpha1.Project, namespace string) *project.ProjectCreateRequest {
 	projSpec := generateProjectSpec(&p.Spec.ForProvider)
+	keepUnmanagedFields(&projSpec, &argocdv1alpha1.AppProjectSpec{}, managedFields(&p.Spec.ForProvider))
 
 	projectCreateRequest := &project.ProjectCreateRequest{
 		Project: &argocdv1alpha1.AppProject{
 			Spec:       projSpec,
-			ObjectMeta: metav1.ObjectMeta{Name: p.Name, Labels: p.Spec.ForProvider.ProjectLabels},
+			ObjectMeta: metav1.ObjectMeta{Name: p.Name, Namespace: namespace, Labels: p.Spec.ForProvider.ProjectLabels},
 		},
 		Upsert: false,
 	}
@@ -331,16 +711,23 @@ func generateProjectSpec(p *v1alpha1.ProjectParameters) argocdv1alpha1.AppProjec
 	projSpec := argocdv1alpha1.AppProjectSpec{}
 
 	if p.SourceRepos != nil {
-		projSpec.SourceRepos = p.SourceRepos
+		projSpec.SourceRepos = withoutDuplicates(p.SourceRepos)
 	}
 	if p.Destinations != nil {
-		projSpec.Destinations = make([]argocdv1alpha1.ApplicationDestination, len(p.Destinations))
-		for i, r := range p.Destinations {
-			projSpec.Destinations[i] = argocdv1alpha1.ApplicationDestination{
+		projSpec.Destinations = make([]argocdv1alpha1.ApplicationDestination, 0, len(p.Destinations))
+		seen := make(map[string]bool, len(p.Destinations))
+		for _, r := range p.Destinations {
+			d := argocdv1alpha1.ApplicationDestination{
 				Server:    clients.StringValue(r.Server),
 				Namespace: clients.StringValue(r.Namespace),
 				Name:      clients.StringValue(r.Name),
 			}
+			key := destinationKey(d.Server, d.Name, d.Namespace)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			projSpec.Destinations = append(projSpec.Destinations, d)
 		}
 	}
 	if p.Description != nil {
@@ -350,13 +737,17 @@ func generateProjectSpec(p *v1alpha1.ProjectParameters) argocdv1alpha1.AppProjec
 		projSpec.Roles = make([]argocdv1alpha1.ProjectRole, len(p.Roles))
 		for i, r := range p.Roles {
 
-			jwtTokens := make([]argocdv1alpha1.JWTToken, len(r.JWTTokens))
-			for j, t := range r.JWTTokens {
-				jwtTokens[j] = argocdv1alpha1.JWTToken{
+			// Named tokens are created by the provider, see createNamedTokens.
+			jwtTokens := make([]argocdv1alpha1.JWTToken, 0, len(r.JWTTokens))
+			for _, t := range r.JWTTokens {
+				if t.Name != nil {
+					continue
+				}
+				jwtTokens = append(jwtTokens, argocdv1alpha1.JWTToken{
 					IssuedAt:  t.IssuedAt,
 					ExpiresAt: clients.Int64Value(t.ExpiresAt),
 					ID:        clients.StringValue(t.ID),
-				}
+				})
 			}
 
 			projSpec.Roles[i] = argocdv1alpha1.ProjectRole{
@@ -390,19 +781,7 @@ func generateProjectSpec(p *v1alpha1.ProjectParameters) argocdv1alpha1.AppProjec
 
 	}
 	if p.SyncWindows != nil {
-		projSpec.SyncWindows = make([]*argocdv1alpha1.SyncWindow, len(p.SyncWindows))
-
-		for i, r := range p.SyncWindows {
-			projSpec.SyncWindows[i] = &argocdv1alpha1.SyncWindow{
-				Kind:         clients.StringValue(r.Kind),
-				Schedule:     clients.StringValue(r.Schedule),
-				Duration:     clients.StringValue(r.Duration),
-				Applications: r.Applications,
-				Namespaces:   r.Namespaces,
-				Clusters:     r.Clusters,
-				ManualSync:   clients.BoolValue(r.ManualSync),
-			}
-		}
+		projSpec.SyncWindows = generateSyncWindows(p.SyncWindows)
 	}
 	if p.NamespaceResourceWhitelist != nil {
 		projSpec.NamespaceResourceWhitelist = p.NamespaceResourceWhitelist
@@ -422,8 +801,44 @@ func generateProjectSpec(p *v1alpha1.ProjectParameters) argocdv1alpha1.AppProjec
 	return projSpec
 }
 
+func generateSyncWindows(p v1alpha1.SyncWindows) argocdv1alpha1.SyncWindows {
+	windows := make(argocdv1alpha1.SyncWindows, len(p))
+	for i, r := range p {
+		windows[i] = &argocdv1alpha1.SyncWindow{
+			Kind:         clients.StringValue(r.Kind),
+			Schedule:     clients.StringValue(r.Schedule),
+			Duration:     clients.StringValue(r.Duration),
+			Applications: r.Applications,
+			Namespaces:   r.Namespaces,
+			Clusters:     r.Clusters,
+			ManualSync:   clients.BoolValue(r.ManualSync),
+			TimeZone:     clients.StringValue(r.TimeZone),
+		}
+	}
+	return windows
+}
+
 func generateUpdateProjectOptions(p *v1alpha1.Project, current *argocdv1alpha1.AppProject) *project.ProjectUpdateRequest {
 	projSpec := generateProjectSpec(&p.Spec.ForProvider)
+	// Unmanaged roles are kept as they are, including their tokens.
+	if p.Spec.ForProvider.Roles == nil {
+		projSpec.Roles = current.Spec.Roles
+	}
+	// Unmanaged sync windows are kept as they are.
+	if p.Spec.ForProvider.SyncWindows == nil {
+		projSpec.SyncWindows = current.Spec.SyncWindows
+	}
+	// Expired named tokens are dropped, so that they are replaced.
+	for i, r := range p.Spec.ForProvider.Roles {
+		for _, t := range r.JWTTokens {
+			for _, nt := range namedTokens(r.Name, t) {
+				if existing, ok := findJWTToken(current, r.Name, nt.id); ok {
+					projSpec.Roles[i].JWTTokens = append(projSpec.Roles[i].JWTTokens, existing)
+				}
+			}
+		}
+	}
+	keepUnmanagedFields(&projSpec, &current.Spec, managedFields(&p.Spec.ForProvider))
 
 	o := &project.ProjectUpdateRequest{
 		Project: &argocdv1alpha1.AppProject{
@@ -439,18 +854,19 @@ func generateUpdateProjectOptions(p *v1alpha1.Project, current *argocdv1alpha1.A
 }
 
 func isProjectUpToDate(p *v1alpha1.ProjectParameters, r *argocdv1alpha1.AppProject) bool { // nolint:gocyclo // checking all parameters can't be reduced
+	m := managedFields(p)
 	switch {
-	case !cmp.Equal(p.SourceRepos, r.Spec.SourceRepos),
-		!isEqualDestinations(p.Destinations, r.Spec.Destinations),
-		clients.StringValue(p.Description) != r.Spec.Description,
-		!isEqualRoles(p.Roles, r.Spec.Roles),
-		!cmp.Equal(p.ClusterResourceWhitelist, r.Spec.ClusterResourceWhitelist),
-		!cmp.Equal(p.NamespaceResourceBlacklist, r.Spec.NamespaceResourceBlacklist),
-		!isEqualOrphanedResources(p.OrphanedResources, r.Spec.OrphanedResources),
-		!isEqualSyncWindows(p.SyncWindows, r.Spec.SyncWindows),
-		!cmp.Equal(p.NamespaceResourceWhitelist, r.Spec.NamespaceResourceWhitelist),
-		!isEqualSignatureKeys(p.SignatureKeys, r.Spec.SignatureKeys),
-		!cmp.Equal(p.ClusterResourceBlacklist, r.Spec.ClusterResourceBlacklist):
+	case m.has(v1alpha1.ProjectFieldSourceRepos) && !isEqualSourceRepos(p.SourceRepos, r.Spec.SourceRepos, clients.BoolValue(p.PreserveSourceReposOrder)),
+		m.has(v1alpha1.ProjectFieldDestinations) && !isEqualDestinations(p.Destinations, r.Spec.Destinations),
+		m.has(v1alpha1.ProjectFieldDescription) && clients.StringValue(p.Description) != r.Spec.Description,
+		m.has(v1alpha1.ProjectFieldRoles) && p.Roles != nil && !isEqualRoles(p.Roles, r.Spec.Roles),
+		m.has(v1alpha1.ProjectFieldClusterResourceWhitelist) && !cmp.Equal(p.ClusterResourceWhitelist, r.Spec.ClusterResourceWhitelist, cmpopts.EquateEmpty()),
+		m.has(v1alpha1.ProjectFieldNamespaceResourceBlacklist) && !cmp.Equal(p.NamespaceResourceBlacklist, r.Spec.NamespaceResourceBlacklist, cmpopts.EquateEmpty()),
+		m.has(v1alpha1.ProjectFieldOrphanedResources) && !isEqualOrphanedResources(p.OrphanedResources, r.Spec.OrphanedResources),
+		m.has(v1alpha1.ProjectFieldSyncWindows) && p.SyncWindows != nil && !projects.IsSyncWindowsEqual(generateSyncWindows(p.SyncWindows), r.Spec.SyncWindows),
+		m.has(v1alpha1.ProjectFieldNamespaceResourceWhitelist) && !cmp.Equal(p.NamespaceResourceWhitelist, r.Spec.NamespaceResourceWhitelist, cmpopts.EquateEmpty()),
+		m.has(v1alpha1.ProjectFieldSignatureKeys) && !isEqualSignatureKeys(p.SignatureKeys, r.Spec.SignatureKeys),
+		m.has(v1alpha1.ProjectFieldClusterResourceBlacklist) && !cmp.Equal(p.ClusterResourceBlacklist, r.Spec.ClusterResourceBlacklist, cmpopts.EquateEmpty()):
 		return false
 	}
 	return true
@@ -467,7 +883,7 @@ func isEqualRoles(p []v1alpha1.ProjectRole, r []argocdv1alpha1.ProjectRole) bool
 		switch {
 		case role.Name != r[i].Name,
 			role.Description != nil && *role.Description != r[i].Description,
-			!cmp.Equal(role.Policies, r[i].Policies),
+			!isEqualPolicies(role.Policies, r[i].Policies),
 			!cmp.Equal(role.Groups, r[i].Groups),
 			!isEqualJWTTokens(role.JWTTokens, r[i].JWTTokens):
 			return false
@@ -477,6 +893,34 @@ func isEqualRoles(p []v1alpha1.ProjectRole, r []argocdv1alpha1.ProjectRole) bool
 }
 
 func isEqualJWTTokens(p []v1alpha1.JWTToken, r []argocdv1alpha1.JWTToken) bool {
+	// Named tokens only need to exist and not be expired. They are matched by
+	// ID, and the other tokens are compared as usual.
+	named := map[string]bool{}
+	var unnamed []v1alpha1.JWTToken
+	for _, t := range p {
+		if t.Name != nil {
+			for _, nt := range namedTokens("", t) {
+				named[nt.id] = true
+			}
+			continue
+		}
+		unnamed = append(unnamed, t)
+	}
+	if len(named) > 0 {
+		var rest []argocdv1alpha1.JWTToken
+		for _, t := range r {
+			if named[t.ID] {
+				if isExpired(t) {
+					return false
+				}
+				delete(named, t.ID)
+				continue
+			}
+			rest = append(rest, t)
+		}
+		return len(named) == 0 && len(unnamed) == len(rest) && (len(unnamed) == 0 || isEqualJWTTokens(unnamed, rest))
+	}
+
 	if p == nil && r == nil {
 		return true
 	}
@@ -494,22 +938,55 @@ func isEqualJWTTokens(p []v1alpha1.JWTToken, r []argocdv1alpha1.JWTToken) bool {
 	return true
 }
 
-func isEqualDestinations(p []v1alpha1.ApplicationDestination, r []argocdv1alpha1.ApplicationDestination) bool { // nolint:gocyclo // checking all parameters can't be reduced
-	if p == nil && r == nil {
+// isEqualSourceRepos compares source repositories as an unordered set,
+// ignoring duplicates. If ordered is true, the order of their first
+// occurrence has to match as well, unless they contain the wildcard, which
+// matches every repository regardless of its position.
+func isEqualSourceRepos(p []string, r []string, ordered bool) bool {
+	if len(p) == 0 && len(r) == 0 {
 		return true
 	}
-	if p == nil || r == nil || len(p) != len(r) {
+	if p == nil || r == nil {
 		return false
 	}
-	for i, destination := range p {
-		switch {
-		case destination.Name != nil && *destination.Name != r[i].Name,
-			destination.Namespace != nil && *destination.Namespace != r[i].Namespace,
-			destination.Server != nil && *destination.Server != r[i].Server:
-			return false
-		}
+	if ordered && !containsString(p, sourceRepoWildcard) {
+		return cmp.Equal(withoutDuplicates(p), withoutDuplicates(r))
 	}
-	return true
+	want := make(map[string]struct{}, len(p))
+	for _, repo := range p {
+		want[repo] = struct{}{}
+	}
+	got := make(map[string]struct{}, len(r))
+	for _, repo := range r {
+		got[repo] = struct{}{}
+	}
+	return cmp.Equal(want, got)
+}
+
+// isEqualDestinations compares destinations as an unordered set, ignoring
+// duplicates.
+func isEqualDestinations(p []v1alpha1.ApplicationDestination, r []argocdv1alpha1.ApplicationDestination) bool {
+	if len(p) == 0 && len(r) == 0 {
+		return true
+	}
+	if p == nil || r == nil {
+		return false
+	}
+	want := make(map[string]struct{}, len(p))
+	for _, d := range p {
+		want[destinationKey(clients.StringValue(d.Server), clients.StringValue(d.Name), clients.StringValue(d.Namespace))] = struct{}{}
+	}
+	got := make(map[string]struct{}, len(r))
+	for _, d := range r {
+		got[destinationKey(d.Server, d.Name, d.Namespace)] = struct{}{}
+	}
+	return cmp.Equal(want, got)
+}
+
+// destinationKey identifies a destination by its cluster, either server or
+// name, and namespace.
+func destinationKey(server, name, namespace string) string {
+	return server + "|" + name + "|" + namespace
 }
 
 func isEqualOrphanedResources(p *v1alpha1.OrphanedResourcesMonitorSettings, r *argocdv1alpha1.OrphanedResourcesMonitorSettings) bool { // nolint:gocyclo // checking all parameters can't be reduced
@@ -520,7 +997,7 @@ func isEqualOrphanedResources(p *v1alpha1.OrphanedResourcesMonitorSettings, r *a
 		return false
 	}
 	switch {
-	case *p.Warn != *r.Warn,
+	case p.Warn != nil && !cmp.Equal(p.Warn, r.Warn),
 		!isEqualOrphanedResourceKeys(p.Ignore, r.Ignore):
 		return false
 	}
@@ -528,7 +1005,7 @@ func isEqualOrphanedResources(p *v1alpha1.OrphanedResourcesMonitorSettings, r *a
 }
 
 func isEqualOrphanedResourceKeys(p []v1alpha1.OrphanedResourceKey, r []argocdv1alpha1.OrphanedResourceKey) bool { // nolint:gocyclo // checking all parameters can't be reduced
-	if p == nil && r == nil {
+	if len(p) == 0 && len(r) == 0 {
 		return true
 	}
 	if p == nil || r == nil || len(p) != len(r) {
@@ -546,7 +1023,7 @@ func isEqualOrphanedResourceKeys(p []v1alpha1.OrphanedResourceKey, r []argocdv1a
 }
 
 func isEqualSignatureKeys(p []v1alpha1.SignatureKey, r []argocdv1alpha1.SignatureKey) bool {
-	if p == nil && r == nil {
+	if len(p) == 0 && len(r) == 0 {
 		return true
 	}
 	if p == nil || r == nil || len(p) != len(r) {
@@ -559,25 +1036,3 @@ func isEqualSignatureKeys(p []v1alpha1.SignatureKey, r []argocdv1alpha1.Signatur
 	}
 	return true
 }
-
-func isEqualSyncWindows(p v1alpha1.SyncWindows, r argocdv1alpha1.SyncWindows) bool { // nolint:gocyclo // checking all parameters can't be reduced
-	if len(p) == 0 && r == nil {
-		return true
-	}
-	if p == nil || r == nil || len(p) != len(r) {
-		return false
-	}
-	for i, syncWindow := range p {
-		switch {
-		case syncWindow.Kind != nil && *syncWindow.Kind != r[i].Kind,
-			syncWindow.Schedule != nil && *syncWindow.Schedule != r[i].Schedule,
-			syncWindow.Duration != nil && *syncWindow.Duration != r[i].Duration,
-			syncWindow.Applications != nil && !cmp.Equal(syncWindow.Applications, r[i].Applications),
-			syncWindow.Namespaces != nil && !cmp.Equal(syncWindow.Namespaces, r[i].Namespaces),
-			syncWindow.Clusters != nil && !cmp.Equal(syncWindow.Clusters, r[i].Clusters),
-			syncWindow.ManualSync != nil && *syncWindow.ManualSync != r[i].ManualSync:
-			return false
-		}
-	}
-	return true
-}
@@ -18,22 +18,38 @@ package projects
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	applicationsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	repositoriesv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
 	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/projects"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/projects"
 )
@@ -41,10 +57,75 @@ import (
 var (
 	errBoom                 = errors.New("boom")
 	errNotFound             = errors.New("code = NotFound desc = appprojects")
+	errUnavailable          = status.Error(codes.Unavailable, "unexpected HTTP status code received from server: 503 (Service Unavailable)")
+	errUnreachable          = status.Error(codes.Unavailable, "connection error: desc = \"transport: Error while dialing: dial tcp 10.0.0.1:443: connect: connection refused\"")
 	testProjectExternalName = "testproject"
+	testArgoCDNamespace     = "argocd-system"
 	testDescription         = "This is a Test"
 	testDescription2        = "This description changed"
 	testLabels              = map[string]string{"label1": "value1"}
+	testSchedule            = "10 1 * * *"
+	testSyncWindows         = v1alpha1.SyncWindows{
+		{Kind: ptr.To("allow"), Schedule: &testSchedule, Duration: ptr.To("1h"), Applications: []string{"*"}},
+		{Kind: ptr.To("deny"), Schedule: &testSchedule, Duration: ptr.To("1h"), Applications: []string{"app"}},
+	}
+	testServer       = "https://kubernetes.default.svc"
+	testNamespace1   = "team-a"
+	testNamespace2   = "team-b"
+	testDestinations = []v1alpha1.ApplicationDestination{
+		{Server: &testServer, Namespace: &testNamespace1},
+		{Server: &testServer, Namespace: &testNamespace2},
+	}
+	// testPartiallyManagedProject manages only the source repos and
+	// destinations. The description is managed by another tool.
+	testPartiallyManagedProject = v1alpha1.ProjectParameters{
+		SourceRepos:   testSourceRepos,
+		Destinations:  testDestinations[:1],
+		ManagedFields: []v1alpha1.ProjectField{v1alpha1.ProjectFieldSourceRepos, v1alpha1.ProjectFieldDestinations},
+	}
+	testDeniedNamespace     = "!kube-system"
+	testNegatedDestinations = []v1alpha1.ApplicationDestination{
+		{Server: &testServer, Namespace: ptr.To("*")},
+		{Server: &testServer, Namespace: &testDeniedNamespace},
+	}
+	testRepo             = "https://github.com/crossplane-contrib/provider-argocd"
+	testMirrorRepo       = "https://gitlab.com/crossplane-contrib/provider-argocd"
+	testSourceRepos      = []string{"*", testRepo}
+	testDeployerPolicies = []string{
+		"p, proj:testproject:deployer, applications, sync, testproject/*, allow",
+		"p, proj:testproject:deployer, applications, delete, testproject/*, deny",
+	}
+	testRoleCI = argocdv1alpha1.ProjectRole{
+		Name:      "ci",
+		Policies:  []string{"p, proj:testproject:ci, applications, sync, testproject/*, allow"},
+		JWTTokens: []argocdv1alpha1.JWTToken{{IssuedAt: 1700000000, ID: "ci-token"}},
+	}
+	testRoleReadOnly = argocdv1alpha1.ProjectRole{
+		Name:     "readonly",
+		Policies: []string{"p, proj:testproject:readonly, applications, get, testproject/*, allow"},
+	}
+	testRoleCIParameters = v1alpha1.ProjectRole{
+		Name:      testRoleCI.Name,
+		Policies:  testRoleCI.Policies,
+		JWTTokens: []v1alpha1.JWTToken{{IssuedAt: 1700000000, ID: ptr.To("ci-token")}},
+	}
+	testTokenName         = "deploy"
+	testTokenJWT          = "eyJhbGciOiJIUzI1NiJ9.deploy"
+	testNamedToken        = argocdv1alpha1.JWTToken{IssuedAt: 1700000000, ID: testTokenName}
+	testOrphanedResources = &argocdv1alpha1.OrphanedResourcesMonitorSettings{Warn: ptr.To(true)}
+	testRoleCINamedToken  = v1alpha1.ProjectRole{
+		Name:      testRoleCI.Name,
+		Policies:  testRoleCI.Policies,
+		JWTTokens: []v1alpha1.JWTToken{{Name: &testTokenName}},
+	}
+	testRoleCIRotatedTokens = v1alpha1.ProjectRole{
+		Name:      testRoleCI.Name,
+		Policies:  testRoleCI.Policies,
+		JWTTokens: []v1alpha1.JWTToken{{Name: &testTokenName, ExpiresIn: &metav1.Duration{Duration: 24 * time.Hour}, Count: ptr.To[int32](2)}},
+	}
+	// The oldest token expired long ago, the newer one expires in 2100.
+	testExpiredToken = argocdv1alpha1.JWTToken{IssuedAt: 1600000000, ExpiresAt: 1600086400, ID: testTokenName + "-0"}
+	testActiveToken  = argocdv1alpha1.JWTToken{IssuedAt: 1700000000, ExpiresAt: 4102444800, ID: testTokenName + "-1"}
 )
 
 type args struct {
@@ -61,6 +142,28 @@ func withMockClient(t *testing.T, mod mockModifier) *mockclient.MockProjectServi
 	return mock
 }
 
+// expectRoles returns an Update function asserting the roles of the updated
+// project.
+func expectRoles(t *testing.T, want []argocdv1alpha1.ProjectRole) func(context.Context, *project.ProjectUpdateRequest, ...grpc.CallOption) (*argocdv1alpha1.AppProject, error) {
+	return func(_ context.Context, req *project.ProjectUpdateRequest, _ ...grpc.CallOption) (*argocdv1alpha1.AppProject, error) {
+		if diff := cmp.Diff(want, req.Project.Spec.Roles, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("roles: -want, +got:\n%s", diff)
+		}
+		return req.Project, nil
+	}
+}
+
+// expectSpec returns an Update function asserting the spec of the updated
+// project.
+func expectSpec(t *testing.T, want argocdv1alpha1.AppProjectSpec) func(context.Context, *project.ProjectUpdateRequest, ...grpc.CallOption) (*argocdv1alpha1.AppProject, error) {
+	return func(_ context.Context, req *project.ProjectUpdateRequest, _ ...grpc.CallOption) (*argocdv1alpha1.AppProject, error) {
+		if diff := cmp.Diff(want, req.Project.Spec, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("spec: -want, +got:\n%s", diff)
+		}
+		return req.Project, nil
+	}
+}
+
 func Project(m ...ProjectModifier) *v1alpha1.Project {
 	cr := &v1alpha1.Project{}
 	for _, f := range m {
@@ -153,7 +256,7 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
-		"SuccessfulLateInitialize": {
+		"DestinationsReordered": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
 					mcs.EXPECT().Get(
@@ -163,26 +266,32 @@ func TestObserve(t *testing.T) {
 						},
 					).Return(
 						&argocdv1alpha1.AppProject{
-							TypeMeta: metav1.TypeMeta{},
 							ObjectMeta: metav1.ObjectMeta{
 								Name: testProjectExternalName,
 							},
 							Spec: argocdv1alpha1.AppProjectSpec{
 								Description: testDescription,
+								Destinations: []argocdv1alpha1.ApplicationDestination{
+									{Server: testServer, Namespace: testNamespace2},
+									{Server: testServer, Namespace: testNamespace1},
+								},
 							},
-							Status: argocdv1alpha1.AppProjectStatus{},
 						}, nil)
 				}),
 				cr: Project(
 					withExternalName(testProjectExternalName),
-					withSpec(v1alpha1.ProjectParameters{}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description:  &testDescription,
+						Destinations: testDestinations,
+					}),
 				),
 			},
 			want: want{
 				cr: Project(
 					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription,
+						Description:  &testDescription,
+						Destinations: testDestinations,
 					}),
 					withConditions(xpv1.Available()),
 					withObservation(v1alpha1.ProjectObservation{
@@ -192,12 +301,12 @@ func TestObserve(t *testing.T) {
 				result: managed.ExternalObservation{
 					ResourceExists:          true,
 					ResourceUpToDate:        true,
-					ResourceLateInitialized: true,
+					ResourceLateInitialized: false,
 				},
 				err: nil,
 			},
 		},
-		"LabelsNotUpToDate": {
+		"NegatedDestinationUpToDate": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
 					mcs.EXPECT().Get(
@@ -207,20 +316,23 @@ func TestObserve(t *testing.T) {
 						},
 					).Return(
 						&argocdv1alpha1.AppProject{
-							TypeMeta: metav1.TypeMeta{},
 							ObjectMeta: metav1.ObjectMeta{
 								Name: testProjectExternalName,
 							},
 							Spec: argocdv1alpha1.AppProjectSpec{
 								Description: testDescription,
+								Destinations: []argocdv1alpha1.ApplicationDestination{
+									{Server: testServer, Namespace: testDeniedNamespace},
+									{Server: testServer, Namespace: "*"},
+								},
 							},
-							Status: argocdv1alpha1.AppProjectStatus{},
 						}, nil)
 				}),
 				cr: Project(
 					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription2,
+						Description:  &testDescription,
+						Destinations: testNegatedDestinations,
 					}),
 				),
 			},
@@ -228,7 +340,8 @@ func TestObserve(t *testing.T) {
 				cr: Project(
 					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription2,
+						Description:  &testDescription,
+						Destinations: testNegatedDestinations,
 					}),
 					withConditions(xpv1.Available()),
 					withObservation(v1alpha1.ProjectObservation{
@@ -237,13 +350,13 @@ func TestObserve(t *testing.T) {
 				),
 				result: managed.ExternalObservation{
 					ResourceExists:          true,
-					ResourceUpToDate:        false,
+					ResourceUpToDate:        true,
 					ResourceLateInitialized: false,
 				},
 				err: nil,
 			},
 		},
-		"GetProjectFailed": {
+		"NegatedDestinationChanged": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
 					mcs.EXPECT().Get(
@@ -252,20 +365,48 @@ func TestObserve(t *testing.T) {
 							Name: testProjectExternalName,
 						},
 					).Return(
-						nil, errBoom)
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Destinations: []argocdv1alpha1.ApplicationDestination{
+									{Server: testServer, Namespace: "*"},
+									{Server: testServer, Namespace: "kube-system"},
+								},
+							},
+						}, nil)
 				}),
 				cr: Project(
 					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description:  &testDescription,
+						Destinations: testNegatedDestinations,
+					}),
 				),
 			},
 			want: want{
 				cr: Project(
 					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description:  &testDescription,
+						Destinations: testNegatedDestinations,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
 				),
-				err: errors.Wrap(errBoom, errGetFailed),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
 			},
 		},
-		"GetProjectNotFound": {
+		"PartiallyManagedUpToDate": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
 					mcs.EXPECT().Get(
@@ -274,178 +415,133 @@ func TestObserve(t *testing.T) {
 							Name: testProjectExternalName,
 						},
 					).Return(
-						nil, errNotFound)
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription2,
+								SourceRepos: testSourceRepos,
+								Destinations: []argocdv1alpha1.ApplicationDestination{
+									{Server: testServer, Namespace: testNamespace1},
+								},
+							},
+						}, nil)
 				}),
 				cr: Project(
 					withExternalName(testProjectExternalName),
+					withSpec(testPartiallyManagedProject),
 				),
 			},
 			want: want{
 				cr: Project(
 					withExternalName(testProjectExternalName),
+					withSpec(testPartiallyManagedProject),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
 				),
-				result: managed.ExternalObservation{},
-				err:    nil,
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
-			o, err := e.Observe(context.Background(), tc.args.cr)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.result, o); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestCreate(t *testing.T) {
-	type want struct {
-		cr     *v1alpha1.Project
-		result managed.ExternalCreation
-		err    error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"Successful": {
+		"PartiallyManagedChanged": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
-					mcs.EXPECT().Create(
+					mcs.EXPECT().Get(
 						context.Background(),
-						&project.ProjectCreateRequest{
-							Project: &argocdv1alpha1.AppProject{
-								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName, Labels: testLabels},
-								Spec: argocdv1alpha1.AppProjectSpec{
-									Description: testDescription,
-								},
-							},
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
 						},
 					).Return(
 						&argocdv1alpha1.AppProject{
-							TypeMeta: metav1.TypeMeta{},
 							ObjectMeta: metav1.ObjectMeta{
-								Name:   testProjectExternalName,
-								Labels: testLabels,
+								Name: testProjectExternalName,
 							},
 							Spec: argocdv1alpha1.AppProjectSpec{
-								Description: testDescription,
+								Description: testDescription2,
+								SourceRepos: testSourceRepos,
+								Destinations: []argocdv1alpha1.ApplicationDestination{
+									{Server: testServer, Namespace: testNamespace2},
+								},
 							},
-							Status: argocdv1alpha1.AppProjectStatus{},
 						}, nil)
 				}),
 				cr: Project(
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
-					}),
-					withSpec(v1alpha1.ProjectParameters{
-						Description:   &testDescription,
-						ProjectLabels: testLabels,
-					}),
+					withExternalName(testProjectExternalName),
+					withSpec(testPartiallyManagedProject),
 				),
 			},
 			want: want{
 				cr: Project(
-					withSpec(v1alpha1.ProjectParameters{
-						Description:   &testDescription,
-						ProjectLabels: testLabels,
-					}),
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
-					}),
 					withExternalName(testProjectExternalName),
+					withSpec(testPartiallyManagedProject),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
 				),
-				result: managed.ExternalCreation{
-					ExternalNameAssigned: true,
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
 				},
 				err: nil,
 			},
 		},
-		"CreateSystemFailed": {
+		"SourceReposWildcardReordered": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
-					mcs.EXPECT().Create(
+					mcs.EXPECT().Get(
 						context.Background(),
-						&project.ProjectCreateRequest{
-							Project: &argocdv1alpha1.AppProject{
-								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
-								Spec: argocdv1alpha1.AppProjectSpec{
-									Description: testDescription,
-								},
-							},
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
 						},
 					).Return(
-						nil, errBoom)
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								SourceRepos: []string{testRepo, "*"},
+							},
+						}, nil)
 				}),
 				cr: Project(
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
-					}),
+					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
 						Description: &testDescription,
+						SourceRepos: testSourceRepos,
 					}),
-					withExternalName(testProjectExternalName),
 				),
 			},
 			want: want{
 				cr: Project(
+					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
 						Description: &testDescription,
+						SourceRepos: testSourceRepos,
 					}),
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
 					}),
-					withExternalName(testProjectExternalName),
 				),
-				result: managed.ExternalCreation{},
-				err:    errors.Wrap(errBoom, errCreateFailed),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
-			o, err := e.Create(context.Background(), tc.args.cr)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.result, o); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestUpdate(t *testing.T) {
-	type want struct {
-		cr     *v1alpha1.Project
-		result managed.ExternalUpdate
-		err    error
-	}
-
-	annotations := make(map[string]string)
-	annotations["crossplane.io/external-name"] = testProjectExternalName
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"Successful": {
+		"SourceReposOrderPreservedReordered": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
 					mcs.EXPECT().Get(
@@ -455,68 +551,46 @@ func TestUpdate(t *testing.T) {
 						},
 					).Return(
 						&argocdv1alpha1.AppProject{
-							TypeMeta: metav1.TypeMeta{},
 							ObjectMeta: metav1.ObjectMeta{
-								Name:   testProjectExternalName,
-								Labels: testLabels,
+								Name: testProjectExternalName,
 							},
 							Spec: argocdv1alpha1.AppProjectSpec{
 								Description: testDescription,
+								SourceRepos: []string{testMirrorRepo, testRepo},
 							},
-							Status: argocdv1alpha1.AppProjectStatus{},
 						}, nil)
-					mcs.EXPECT().Update(
-						context.Background(),
-						gomock.Any(), // FIXME project.ProjectUpdateRequest objects can't be matched by gomock
-						// project.ProjectUpdateRequest{
-						// 	Project: &argocdv1alpha1.AppProject{
-						// 		ObjectMeta: metav1.ObjectMeta{
-						// 			Name:            testProjectExternalName,
-						// 		},
-						// 		Spec: argocdv1alpha1.AppProjectSpec{
-						// 			Description: testDescription2,
-						// 		},
-						// 	},
-						// },
-					).Return(&argocdv1alpha1.AppProject{
-						TypeMeta: metav1.TypeMeta{},
-						ObjectMeta: metav1.ObjectMeta{
-							Name:   testProjectExternalName,
-							Labels: testLabels,
-						},
-						Spec: argocdv1alpha1.AppProjectSpec{
-							Description: testDescription2,
-						},
-						Status: argocdv1alpha1.AppProjectStatus{},
-					}, nil)
 				}),
 				cr: Project(
-					withObjectMeta(metav1.ObjectMeta{
-						Name:   testProjectExternalName,
-						Labels: testLabels,
-					}),
+					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription2,
+						Description:              &testDescription,
+						SourceRepos:              []string{testRepo, testMirrorRepo},
+						PreserveSourceReposOrder: ptr.To(true),
 					}),
-					withExternalName(testProjectExternalName),
 				),
 			},
 			want: want{
 				cr: Project(
+					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription2,
+						Description:              &testDescription,
+						SourceRepos:              []string{testRepo, testMirrorRepo},
+						PreserveSourceReposOrder: ptr.To(true),
 					}),
-					withObjectMeta(metav1.ObjectMeta{
-						Name:   testProjectExternalName,
-						Labels: testLabels,
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
 					}),
-					withExternalName(testProjectExternalName),
 				),
-				result: managed.ExternalUpdate{},
-				err:    nil,
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
 			},
 		},
-		"ProjectNotFound": {
+		"RolesUnmanaged": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
 					mcs.EXPECT().Get(
@@ -525,33 +599,90 @@ func TestUpdate(t *testing.T) {
 							Name: testProjectExternalName,
 						},
 					).Return(
-						nil, errBoom)
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles:       []argocdv1alpha1.ProjectRole{testRoleCI},
+							},
+						}, nil)
 				}),
 				cr: Project(
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
 					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription2,
+						Description: &testDescription,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
 					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"PolicyEffectChanged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles:       []argocdv1alpha1.ProjectRole{{Name: "deployer", Policies: []string{testDeployerPolicies[0], "p, proj:testproject:deployer, applications, delete, testproject/*, allow"}}},
+							},
+						}, nil)
+				}),
+				cr: Project(
 					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{{Name: "deployer", Policies: testDeployerPolicies}},
+					}),
 				),
 			},
 			want: want{
 				cr: Project(
+					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription2,
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{{Name: "deployer", Policies: testDeployerPolicies}},
 					}),
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
 					}),
-					withExternalName(testProjectExternalName),
 				),
-				result: managed.ExternalUpdate{},
-				err:    errors.Wrap(errBoom, errUpdateFailed),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
 			},
 		},
-		"UpdateProjectFailed": {
+		"PoliciesReordered": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
 					mcs.EXPECT().Get(
@@ -561,99 +692,118 @@ func TestUpdate(t *testing.T) {
 						},
 					).Return(
 						&argocdv1alpha1.AppProject{
-							TypeMeta: metav1.TypeMeta{},
 							ObjectMeta: metav1.ObjectMeta{
 								Name: testProjectExternalName,
 							},
 							Spec: argocdv1alpha1.AppProjectSpec{
 								Description: testDescription,
+								Roles:       []argocdv1alpha1.ProjectRole{{Name: "deployer", Policies: []string{testDeployerPolicies[1], testDeployerPolicies[0]}}},
 							},
-							Status: argocdv1alpha1.AppProjectStatus{},
 						}, nil)
-					mcs.EXPECT().Update(
-						context.Background(),
-						gomock.Any(), // FIXME project.ProjectUpdateRequest objects can't be matched by gomock
-						// project.ProjectUpdateRequest{
-						// 	Project: &argocdv1alpha1.AppProject{
-						// 		ObjectMeta: metav1.ObjectMeta{
-						// 			Name:            testProjectExternalName,
-						// 		},
-						// 		Spec: argocdv1alpha1.AppProjectSpec{
-						// 			Description: testDescription2,
-						// 		},
-						// 	},
-						// },
-					).Return(nil, errBoom)
 				}),
 				cr: Project(
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{{Name: "deployer", Policies: testDeployerPolicies}},
 					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription2,
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{{Name: "deployer", Policies: testDeployerPolicies}},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
 					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"AllRolesRemoved": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles:       []argocdv1alpha1.ProjectRole{testRoleCI},
+							},
+						}, nil)
+				}),
+				cr: Project(
 					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{},
+					}),
 				),
 			},
 			want: want{
 				cr: Project(
+					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
-						Description: &testDescription2,
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{},
 					}),
-					withObjectMeta(metav1.ObjectMeta{
-						Name: testProjectExternalName,
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
 					}),
-					withExternalName(testProjectExternalName),
 				),
-				result: managed.ExternalUpdate{},
-				err:    errors.Wrap(errBoom, errUpdateFailed),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
-			u, err := e.Update(context.Background(), tc.args.cr)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.result, u); diff != "" {
-				t.Errorf("r: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestDelete(t *testing.T) {
-	type want struct {
-		cr  *v1alpha1.Project
-		err error
-	}
-
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"Successful": {
+		"NamedTokenExists": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
-					mcs.EXPECT().Delete(
+					mcs.EXPECT().Get(
 						context.Background(),
 						&project.ProjectQuery{
 							Name: testProjectExternalName,
 						},
 					).Return(
-						&project.EmptyResponse{}, nil)
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles: []argocdv1alpha1.ProjectRole{{
+									Name:      testRoleCI.Name,
+									Policies:  testRoleCI.Policies,
+									JWTTokens: []argocdv1alpha1.JWTToken{testNamedToken},
+								}},
+							},
+						}, nil)
 				}),
 				cr: Project(
 					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
 						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{testRoleCINamedToken},
 					}),
 				),
 			},
@@ -662,26 +812,50 @@ func TestDelete(t *testing.T) {
 					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
 						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole:   map[string]v1alpha1.JWTTokens{},
+						JWTTokenIDsByName: map[string]string{"ci/deploy": testTokenName},
 					}),
 				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
 				err: nil,
 			},
 		},
-		"DeleteFailed": {
+		"CountedTokensActive": {
 			args: args{
 				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
-					mcs.EXPECT().Delete(
+					mcs.EXPECT().Get(
 						context.Background(),
 						&project.ProjectQuery{
 							Name: testProjectExternalName,
 						},
 					).Return(
-						&project.EmptyResponse{}, errBoom)
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles: []argocdv1alpha1.ProjectRole{{
+									Name:      testRoleCI.Name,
+									Policies:  testRoleCI.Policies,
+									JWTTokens: []argocdv1alpha1.JWTToken{testActiveToken, {IssuedAt: 1700000000, ExpiresAt: 4102444800, ID: testTokenName + "-0"}},
+								}},
+							},
+						}, nil)
 				}),
 				cr: Project(
 					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
 						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{testRoleCIRotatedTokens},
 					}),
 				),
 			},
@@ -690,16 +864,1724 @@ func TestDelete(t *testing.T) {
 					withExternalName(testProjectExternalName),
 					withSpec(v1alpha1.ProjectParameters{
 						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{testRoleCIRotatedTokens},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole:   map[string]v1alpha1.JWTTokens{},
+						JWTTokenIDsByName: map[string]string{"ci/deploy-0": testTokenName + "-0", "ci/deploy-1": testTokenName + "-1"},
 					}),
 				),
-				err: errors.Wrap(errBoom, errDeleteFailed),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
 			},
 		},
-	}
-
+		"CountedTokenExpired": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles: []argocdv1alpha1.ProjectRole{{
+									Name:      testRoleCI.Name,
+									Policies:  testRoleCI.Policies,
+									JWTTokens: []argocdv1alpha1.JWTToken{testExpiredToken, testActiveToken},
+								}},
+							},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{testRoleCIRotatedTokens},
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{testRoleCIRotatedTokens},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole:   map[string]v1alpha1.JWTTokens{},
+						JWTTokenIDsByName: map[string]string{"ci/deploy-1": testTokenName + "-1"},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"NamedTokenMissing": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles: []argocdv1alpha1.ProjectRole{{
+									Name:      testRoleCI.Name,
+									Policies:  testRoleCI.Policies,
+									JWTTokens: nil,
+								}},
+							},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+						Roles:       []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole:   map[string]v1alpha1.JWTTokens{},
+						JWTTokenIDsByName: nil,
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"DescriptionRemoved": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withConditions(xpv1.Available()),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"OrphanedResourcesRemoved": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								OrphanedResources: testOrphanedResources,
+							},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withConditions(xpv1.Available()),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"SuccessfulLateInitialize": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				err: nil,
+			},
+		},
+		"LabelsNotUpToDate": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withConditions(xpv1.Available()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        false,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"SuccessfulAvailableWithWarnings": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								SyncWindows: argocdv1alpha1.SyncWindows{
+									{Kind: "allow", Schedule: testSchedule, Duration: "1h", Applications: []string{"*"}},
+									{Kind: "deny", Schedule: testSchedule, Duration: "1h", Applications: []string{"app"}},
+								},
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+						SyncWindows: testSyncWindows,
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+						SyncWindows: testSyncWindows,
+					}),
+					withConditions(
+						xpv1.Available(),
+						apisv1alpha1.ValidationWarning(`sync windows 0 and 1 overlap: allow window with schedule "10 1 * * *" is shadowed by the deny window`),
+					),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"WarningsCleared": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withConditions(apisv1alpha1.ValidationWarning("sync windows 0 and 1 are duplicates")),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withConditions(xpv1.Available(), apisv1alpha1.NoWarnings()),
+					withObservation(v1alpha1.ProjectObservation{
+						JWTTokensByRole: map[string]v1alpha1.JWTTokens{},
+					}),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: false,
+				},
+				err: nil,
+			},
+		},
+		"GetProjectFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						nil, errBoom)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+				err: errors.Wrap(errBoom, errGetFailed),
+			},
+		},
+		"ArgoCDMaintenance": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						nil, errUnavailable)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withConditions(apisv1alpha1.Maintenance()),
+				),
+				result: managed.ExternalObservation{},
+				err:    errors.Wrap(errUnavailable, errGetFailed),
+			},
+		},
+		"ArgoCDUnreachable": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						nil, errUnreachable)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalObservation{},
+				err:    errors.Wrap(errUnreachable, errGetFailed),
+			},
+		},
+		"GetProjectNotFound": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						nil, errNotFound)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalObservation{},
+				err:    nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, lateInitialize: true}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.Project
+		result managed.ExternalCreation
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		namespace string
+		want
+	}{
+		"Successful": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName, Labels: testLabels},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Description: testDescription,
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   testProjectExternalName,
+								Labels: testLabels,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description:   &testDescription,
+						ProjectLabels: testLabels,
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description:   &testDescription,
+						ProjectLabels: testLabels,
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
+		"SuccessfulInArgoCDNamespace": {
+			namespace: testArgoCDNamespace,
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName, Namespace: testArgoCDNamespace},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Description: testDescription,
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      testProjectExternalName,
+								Namespace: testArgoCDNamespace,
+							},
+						}, nil)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
+		"SuccessfulNegatedDestination": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Destinations: []argocdv1alpha1.ApplicationDestination{
+										{Server: testServer, Namespace: "*"},
+										{Server: testServer, Namespace: testDeniedNamespace},
+									},
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+						}, nil)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Destinations: testNegatedDestinations,
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Destinations: testNegatedDestinations,
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
+		"SuccessfulDedupeDestinations": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Destinations: []argocdv1alpha1.ApplicationDestination{
+										{Server: testServer, Namespace: testNamespace1},
+									},
+								},
+							},
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+						}, nil)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Destinations: []v1alpha1.ApplicationDestination{testDestinations[0], testDestinations[0]},
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Destinations: []v1alpha1.ApplicationDestination{testDestinations[0], testDestinations[0]},
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
+		"CreateSystemFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Create(
+						context.Background(),
+						&project.ProjectCreateRequest{
+							Project: &argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Description: testDescription,
+								},
+							},
+						},
+					).Return(
+						nil, errBoom)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalCreation{},
+				err:    errors.Wrap(errBoom, errCreateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, namespace: tc.namespace}
+			o, err := e.Create(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		cr     *v1alpha1.Project
+		result managed.ExternalUpdate
+		err    error
+	}
+
+	annotations := make(map[string]string)
+	annotations["crossplane.io/external-name"] = testProjectExternalName
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"RejectInvalidSyncWindowTimeZone": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						SyncWindows: v1alpha1.SyncWindows{{Schedule: &testSchedule, TimeZone: ptr.To("Mars/Olympus")}},
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						SyncWindows: v1alpha1.SyncWindows{{Schedule: &testSchedule, TimeZone: ptr.To("Mars/Olympus")}},
+					}),
+				),
+				err: errors.Wrapf(errors.New("unknown time zone Mars/Olympus"), errFmtSyncWindowTimeZone, 0, "Mars/Olympus"),
+			},
+		},
+		"RejectPolicyOfOtherProject": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name:     "ci",
+							Policies: []string{"p, proj:otherproject:ci, applications, sync, otherproject/*, allow"},
+						}},
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{{
+							Name:     "ci",
+							Policies: []string{"p, proj:otherproject:ci, applications, sync, otherproject/*, allow"},
+						}},
+					}),
+				),
+				err: errors.Errorf(errFmtPolicySubject, "p, proj:otherproject:ci, applications, sync, otherproject/*, allow", "ci", testProjectExternalName, "ci"),
+			},
+		},
+		"Successful": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   testProjectExternalName,
+								Labels: testLabels,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(), // FIXME project.ProjectUpdateRequest objects can't be matched by gomock
+						// project.ProjectUpdateRequest{
+						// 	Project: &argocdv1alpha1.AppProject{
+						// 		ObjectMeta: metav1.ObjectMeta{
+						// 			Name:            testProjectExternalName,
+						// 		},
+						// 		Spec: argocdv1alpha1.AppProjectSpec{
+						// 			Description: testDescription2,
+						// 		},
+						// 	},
+						// },
+					).Return(&argocdv1alpha1.AppProject{
+						TypeMeta: metav1.TypeMeta{},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   testProjectExternalName,
+							Labels: testLabels,
+						},
+						Spec: argocdv1alpha1.AppProjectSpec{
+							Description: testDescription2,
+						},
+						Status: argocdv1alpha1.AppProjectStatus{},
+					}, nil)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name:   testProjectExternalName,
+						Labels: testLabels,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name:   testProjectExternalName,
+						Labels: testLabels,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SuccessfulRolesUnmanaged": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles:       []argocdv1alpha1.ProjectRole{testRoleCI, testRoleReadOnly},
+							},
+						}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(expectRoles(t, []argocdv1alpha1.ProjectRole{testRoleCI, testRoleReadOnly}))
+				}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SuccessfulRemoveAllRoles": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles:       []argocdv1alpha1.ProjectRole{testRoleCI, testRoleReadOnly},
+							},
+						}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(expectRoles(t, nil))
+				}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+						Roles:       []v1alpha1.ProjectRole{},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+						Roles:       []v1alpha1.ProjectRole{},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SuccessfulRemoveRole": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+								Roles:       []argocdv1alpha1.ProjectRole{testRoleCI, testRoleReadOnly},
+							},
+						}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(expectRoles(t, []argocdv1alpha1.ProjectRole{testRoleCI}))
+				}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+						Roles:       []v1alpha1.ProjectRole{testRoleCIParameters},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+						Roles:       []v1alpha1.ProjectRole{testRoleCIParameters},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SuccessfulCreateNamedToken": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Roles: []argocdv1alpha1.ProjectRole{{
+									Name:      testRoleCI.Name,
+									Policies:  testRoleCI.Policies,
+									JWTTokens: nil,
+								}},
+							},
+						}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(expectRoles(t, []argocdv1alpha1.ProjectRole{{
+						Name:      testRoleCI.Name,
+						Policies:  testRoleCI.Policies,
+						JWTTokens: nil,
+					}}))
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{
+							Project:     testProjectExternalName,
+							Role:        testRoleCI.Name,
+							Id:          testTokenName,
+							Description: testTokenName,
+						},
+					).Return(&project.ProjectTokenResponse{Token: testTokenJWT}, nil)
+				}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{
+					ConnectionDetails: managed.ConnectionDetails{"ci.deploy": []byte(testTokenJWT)},
+				},
+				err: nil,
+			},
+		},
+		"RotateExpiredToken": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Roles: []argocdv1alpha1.ProjectRole{{
+									Name:      testRoleCI.Name,
+									Policies:  testRoleCI.Policies,
+									JWTTokens: []argocdv1alpha1.JWTToken{testExpiredToken, testActiveToken},
+								}},
+							},
+						}, nil)
+					// The expired token is removed, so that its ID is free again.
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(expectRoles(t, []argocdv1alpha1.ProjectRole{{
+						Name:      testRoleCI.Name,
+						Policies:  testRoleCI.Policies,
+						JWTTokens: []argocdv1alpha1.JWTToken{testActiveToken},
+					}}))
+					mcs.EXPECT().CreateToken(
+						context.Background(),
+						&project.ProjectTokenCreateRequest{
+							Project:     testProjectExternalName,
+							Role:        testRoleCI.Name,
+							Id:          testTokenName + "-0",
+							Description: testTokenName + "-0",
+							ExpiresIn:   86400,
+						},
+					).Return(&project.ProjectTokenResponse{Token: testTokenJWT}, nil)
+				}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCIRotatedTokens},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCIRotatedTokens},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{
+					ConnectionDetails: managed.ConnectionDetails{"ci.deploy.0": []byte(testTokenJWT)},
+				},
+				err: nil,
+			},
+		},
+		"NamedTokenNotCreatedTwice": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Roles: []argocdv1alpha1.ProjectRole{{
+									Name:      testRoleCI.Name,
+									Policies:  testRoleCI.Policies,
+									JWTTokens: []argocdv1alpha1.JWTToken{testNamedToken},
+								}},
+							},
+						}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(expectRoles(t, []argocdv1alpha1.ProjectRole{{
+						Name:      testRoleCI.Name,
+						Policies:  testRoleCI.Policies,
+						JWTTokens: []argocdv1alpha1.JWTToken{testNamedToken},
+					}}))
+				}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"SuccessfulClearRemovedFields": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description:       testDescription,
+								OrphanedResources: testOrphanedResources,
+								SourceRepos:       []string{testRepo},
+							},
+						}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(),
+					).DoAndReturn(expectSpec(t, argocdv1alpha1.AppProjectSpec{
+						SourceRepos: []string{testRepo},
+					}))
+				}),
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						SourceRepos: []string{testRepo},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						SourceRepos: []string{testRepo},
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    nil,
+			},
+		},
+		"ProjectNotFound": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						nil, errBoom)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    errors.Wrap(errBoom, errUpdateFailed),
+			},
+		},
+		"UpdateProjectFailed": {
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							TypeMeta: metav1.TypeMeta{},
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Description: testDescription,
+							},
+							Status: argocdv1alpha1.AppProjectStatus{},
+						}, nil)
+					mcs.EXPECT().Update(
+						context.Background(),
+						gomock.Any(), // FIXME project.ProjectUpdateRequest objects can't be matched by gomock
+						// project.ProjectUpdateRequest{
+						// 	Project: &argocdv1alpha1.AppProject{
+						// 		ObjectMeta: metav1.ObjectMeta{
+						// 			Name:            testProjectExternalName,
+						// 		},
+						// 		Spec: argocdv1alpha1.AppProjectSpec{
+						// 			Description: testDescription2,
+						// 		},
+						// 	},
+						// },
+					).Return(nil, errBoom)
+				}),
+				cr: Project(
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription2,
+					}),
+					withObjectMeta(metav1.ObjectMeta{
+						Name: testProjectExternalName,
+					}),
+					withExternalName(testProjectExternalName),
+				),
+				result: managed.ExternalUpdate{},
+				err:    errors.Wrap(errBoom, errUpdateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client}
+			u, err := e.Update(context.Background(), tc.args.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, u); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// eventRecorder sends the recorded events to its channel.
+type eventRecorder chan event.Event
+
+func (r eventRecorder) Event(_ runtime.Object, e event.Event) { r <- e }
+
+func (r eventRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func TestUpdatePartialTokenCreation(t *testing.T) {
+	client := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+		mcs.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.AppProject{
+			ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+			Spec: argocdv1alpha1.AppProjectSpec{
+				Roles: []argocdv1alpha1.ProjectRole{{Name: testRoleCI.Name, Policies: testRoleCI.Policies}},
+			},
+		}, nil)
+		mcs.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.AppProject{}, nil)
+		mcs.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(&project.ProjectTokenResponse{Token: testTokenJWT}, nil)
+		mcs.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil, errBoom)
+	})
+	recorder := make(eventRecorder, 1)
+	e := &external{client: client, recorder: recorder}
+	cr := Project(
+		withSpec(v1alpha1.ProjectParameters{
+			Roles: []v1alpha1.ProjectRole{testRoleCIRotatedTokens},
+		}),
+		withExternalName(testProjectExternalName),
+	)
+
+	u, err := e.Update(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Update(...): %v", err)
+	}
+	// The created token is published, since ArgoCD never returns it again.
+	want := managed.ConnectionDetails{"ci.deploy.0": []byte(testTokenJWT)}
+	if diff := cmp.Diff(want, u.ConnectionDetails); diff != "" {
+		t.Errorf("Update(...): -want connection details, +got connection details:\n%s", diff)
+	}
+	select {
+	case ev := <-recorder:
+		wantEvent := event.Warning(reasonCannotCreateToken, errors.Wrap(errors.Wrapf(errBoom, "role %s, token %s", testRoleCI.Name, testTokenName+"-1"), errCreateToken))
+		if diff := cmp.Diff(wantEvent, ev, test.EquateErrors()); diff != "" {
+			t.Errorf("Update(...): -want event, +got event:\n%s", diff)
+		}
+	default:
+		t.Errorf("Update(...): failed token creation was not recorded")
+	}
+	// The project was updated, so only the tokens are reported as pending.
+	wantCondition := v1alpha1.TokensPending(errors.Wrap(errors.Wrapf(errBoom, "role %s, token %s", testRoleCI.Name, testTokenName+"-1"), errCreateToken).Error())
+	if diff := cmp.Diff(wantCondition, cr.Status.GetCondition(v1alpha1.TypeTokens), test.EquateConditions()); diff != "" {
+		t.Errorf("Update(...): -want condition, +got condition:\n%s", diff)
+	}
+}
+
+func TestUpdatePendingTokensCreated(t *testing.T) {
+	client := withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+		mcs.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.AppProject{
+			ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+			Spec: argocdv1alpha1.AppProjectSpec{
+				Roles: []argocdv1alpha1.ProjectRole{{Name: testRoleCI.Name, Policies: testRoleCI.Policies}},
+			},
+		}, nil)
+		mcs.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&argocdv1alpha1.AppProject{}, nil)
+		mcs.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(&project.ProjectTokenResponse{Token: testTokenJWT}, nil).Times(2)
+	})
+	e := &external{client: client}
+	cr := Project(
+		withSpec(v1alpha1.ProjectParameters{
+			Roles: []v1alpha1.ProjectRole{testRoleCIRotatedTokens},
+		}),
+		withExternalName(testProjectExternalName),
+	)
+	cr.Status.SetConditions(v1alpha1.TokensPending(errCreateToken))
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): %v", err)
+	}
+	if diff := cmp.Diff(v1alpha1.TokensCreated(), cr.Status.GetCondition(v1alpha1.TypeTokens), test.EquateConditions()); diff != "" {
+		t.Errorf("Update(...): -want condition, +got condition:\n%s", diff)
+	}
+}
+
+// withDependents returns a client listing the supplied Applications.
+func withDependents(apps ...applicationsv1alpha1.Application) client.Client {
+	return &test.MockClient{
+		MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			if l, ok := obj.(*applicationsv1alpha1.ApplicationList); ok {
+				l.Items = apps
+			}
+			return nil
+		}),
+	}
+}
+
+// withRepositoryDependents returns a client listing the supplied Repositories.
+func withRepositoryDependents(repos ...repositoriesv1alpha1.Repository) client.Client {
+	return &test.MockClient{
+		MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			if l, ok := obj.(*repositoriesv1alpha1.RepositoryList); ok {
+				l.Items = repos
+			}
+			return nil
+		}),
+	}
+}
+
+func testRepository(project string) repositoriesv1alpha1.Repository {
+	r := repositoriesv1alpha1.Repository{}
+	r.SetName("repo-in-" + project)
+	r.Spec.ForProvider.Project = &project
+	return r
+}
+
+func testApplication(project, providerConfig string) applicationsv1alpha1.Application {
+	a := applicationsv1alpha1.Application{}
+	a.SetName("app-in-" + project)
+	a.Spec.ForProvider.Project = project
+	if providerConfig != "" {
+		a.SetProviderConfigReference(&xpv1.Reference{Name: providerConfig})
+	}
+	return a
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		cr  *v1alpha1.Project
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		kube client.Client
+		want
+	}{
+		"Successful": {
+			kube: withDependents(),
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Delete(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&project.EmptyResponse{}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+				),
+				err: nil,
+			},
+		},
+		"SuccessfulWithApplicationOfOtherArgoCD": {
+			kube: withDependents(testApplication(testProjectExternalName, "other-argocd")),
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Delete(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&project.EmptyResponse{}, nil)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+				err: nil,
+			},
+		},
+		"BlockedByApplication": {
+			kube: withDependents(
+				testApplication(testProjectExternalName, ""),
+				testApplication("otherproject", ""),
+			),
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+				err: errors.Errorf(errFmtDependents, "Application app-in-"+testProjectExternalName),
+			},
+		},
+		"BlockedByRepository": {
+			kube: withRepositoryDependents(
+				testRepository(testProjectExternalName),
+				testRepository("otherproject"),
+			),
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+				err: errors.Errorf(errFmtDependents, "Repository repo-in-"+testProjectExternalName),
+			},
+		},
+		"ListDependentsFailed": {
+			kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+				),
+				err: errors.Wrap(errBoom, errListDependents),
+			},
+		},
+		"RevokeNamedTokens": {
+			kube: withDependents(),
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					gomock.InOrder(
+						mcs.EXPECT().Get(
+							context.Background(),
+							&project.ProjectQuery{
+								Name: testProjectExternalName,
+							},
+						).Return(
+							&argocdv1alpha1.AppProject{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: testProjectExternalName,
+								},
+								Spec: argocdv1alpha1.AppProjectSpec{
+									Roles: []argocdv1alpha1.ProjectRole{{
+										Name:      testRoleCI.Name,
+										Policies:  testRoleCI.Policies,
+										JWTTokens: []argocdv1alpha1.JWTToken{testNamedToken, {IssuedAt: 1600000000}},
+									}},
+								},
+							}, nil),
+						mcs.EXPECT().DeleteToken(
+							context.Background(),
+							&project.ProjectTokenDeleteRequest{
+								Project: testProjectExternalName,
+								Role:    testRoleCI.Name,
+								Id:      testTokenName,
+								Iat:     testNamedToken.IssuedAt,
+							},
+						).Return(&project.EmptyResponse{}, nil),
+						mcs.EXPECT().Delete(
+							context.Background(),
+							&project.ProjectQuery{
+								Name: testProjectExternalName,
+							},
+						).Return(&project.EmptyResponse{}, nil),
+					)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+				),
+				err: nil,
+			},
+		},
+		"RevokeTokenFailed": {
+			kube: withDependents(),
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Get(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&argocdv1alpha1.AppProject{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: testProjectExternalName,
+							},
+							Spec: argocdv1alpha1.AppProjectSpec{
+								Roles: []argocdv1alpha1.ProjectRole{{
+									Name:      testRoleCI.Name,
+									JWTTokens: []argocdv1alpha1.JWTToken{testNamedToken},
+								}},
+							},
+						}, nil)
+					mcs.EXPECT().DeleteToken(context.Background(), gomock.Any()).Return(nil, errBoom)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Roles: []v1alpha1.ProjectRole{testRoleCINamedToken},
+					}),
+				),
+				err: errors.Wrap(errors.Wrapf(errBoom, "role %s, token %s", testRoleCI.Name, testTokenName), errRevokeToken),
+			},
+		},
+		"DeleteFailed": {
+			kube: withDependents(),
+			args: args{
+				client: withMockClient(t, func(mcs *mockclient.MockProjectServiceClient) {
+					mcs.EXPECT().Delete(
+						context.Background(),
+						&project.ProjectQuery{
+							Name: testProjectExternalName,
+						},
+					).Return(
+						&project.EmptyResponse{}, errBoom)
+				}),
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+				),
+			},
+			want: want{
+				cr: Project(
+					withExternalName(testProjectExternalName),
+					withSpec(v1alpha1.ProjectParameters{
+						Description: &testDescription,
+					}),
+				),
+				err: errors.Wrap(errBoom, errDeleteFailed),
+			},
+		},
+	}
+
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{client: tc.client}
+			e := &external{kube: tc.kube, client: tc.client}
 			err := e.Delete(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -711,3 +2593,621 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestLateInitializeProject(t *testing.T) {
+	observed := &argocdv1alpha1.AppProjectSpec{
+		Description:              testDescription,
+		SourceRepos:              []string{testRepo},
+		Destinations:             []argocdv1alpha1.ApplicationDestination{{Server: testServer, Namespace: testNamespace1}},
+		ClusterResourceWhitelist: []metav1.GroupKind{{Group: "*", Kind: "*"}},
+		SignatureKeys:            []argocdv1alpha1.SignatureKey{{KeyID: "4AEE18F83AFDEB23"}},
+		OrphanedResources:        testOrphanedResources,
+	}
+
+	cases := map[string]struct {
+		p    v1alpha1.ProjectParameters
+		want v1alpha1.ProjectParameters
+	}{
+		"Unset": {
+			p: v1alpha1.ProjectParameters{},
+			want: v1alpha1.ProjectParameters{
+				Description:              &testDescription,
+				SourceRepos:              []string{testRepo},
+				Destinations:             []v1alpha1.ApplicationDestination{{Server: &testServer, Namespace: &testNamespace1, Name: ptr.To("")}},
+				ClusterResourceWhitelist: []metav1.GroupKind{{Group: "*", Kind: "*"}},
+				SignatureKeys:            []v1alpha1.SignatureKey{{KeyID: "4AEE18F83AFDEB23"}},
+				OrphanedResources:        &v1alpha1.OrphanedResourcesMonitorSettings{Warn: ptr.To(true)},
+			},
+		},
+		"EmptyListsAreKept": {
+			p: v1alpha1.ProjectParameters{
+				SourceRepos:              []string{},
+				Destinations:             []v1alpha1.ApplicationDestination{},
+				ClusterResourceWhitelist: []metav1.GroupKind{},
+				SignatureKeys:            []v1alpha1.SignatureKey{},
+			},
+			want: v1alpha1.ProjectParameters{
+				Description:              &testDescription,
+				SourceRepos:              []string{},
+				Destinations:             []v1alpha1.ApplicationDestination{},
+				ClusterResourceWhitelist: []metav1.GroupKind{},
+				SignatureKeys:            []v1alpha1.SignatureKey{},
+				OrphanedResources:        &v1alpha1.OrphanedResourcesMonitorSettings{Warn: ptr.To(true)},
+			},
+		},
+		"OrphanedResourcesWarn": {
+			p: v1alpha1.ProjectParameters{
+				OrphanedResources: &v1alpha1.OrphanedResourcesMonitorSettings{
+					Ignore: []v1alpha1.OrphanedResourceKey{{Kind: ptr.To("ConfigMap")}},
+				},
+			},
+			want: v1alpha1.ProjectParameters{
+				Description:              &testDescription,
+				SourceRepos:              []string{testRepo},
+				Destinations:             []v1alpha1.ApplicationDestination{{Server: &testServer, Namespace: &testNamespace1, Name: ptr.To("")}},
+				ClusterResourceWhitelist: []metav1.GroupKind{{Group: "*", Kind: "*"}},
+				SignatureKeys:            []v1alpha1.SignatureKey{{KeyID: "4AEE18F83AFDEB23"}},
+				OrphanedResources: &v1alpha1.OrphanedResourcesMonitorSettings{
+					Warn:   ptr.To(true),
+					Ignore: []v1alpha1.OrphanedResourceKey{{Kind: ptr.To("ConfigMap")}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			lateInitializeProject(&tc.p, observed)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("lateInitializeProject(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEmptyListsSurviveSerialization(t *testing.T) {
+	p := v1alpha1.ProjectParameters{
+		SourceRepos:              []string{},
+		ClusterResourceWhitelist: []metav1.GroupKind{},
+	}
+	raw, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("json.Marshal(...): %v", err)
+	}
+	got := v1alpha1.ProjectParameters{}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("json.Unmarshal(...): %v", err)
+	}
+	if got.SourceRepos == nil || got.ClusterResourceWhitelist == nil {
+		t.Errorf("empty lists became unset after serialization: %s", raw)
+	}
+	if got.Destinations != nil || got.SignatureKeys != nil {
+		t.Errorf("unset lists became empty after serialization: %s", raw)
+	}
+}
+
+func TestIsProjectUpToDateEmptyLists(t *testing.T) {
+	p := &v1alpha1.ProjectParameters{
+		SourceRepos:                []string{},
+		Destinations:               []v1alpha1.ApplicationDestination{},
+		ClusterResourceWhitelist:   []metav1.GroupKind{},
+		NamespaceResourceBlacklist: []metav1.GroupKind{},
+		NamespaceResourceWhitelist: []metav1.GroupKind{},
+		SignatureKeys:              []v1alpha1.SignatureKey{},
+		ClusterResourceBlacklist:   []metav1.GroupKind{},
+	}
+	// ArgoCD omits empty lists.
+	r := &argocdv1alpha1.AppProject{ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName}}
+
+	if !isProjectUpToDate(p, r) {
+		t.Errorf("isProjectUpToDate(...): empty lists are reported as drift from unset lists")
+	}
+
+	r.Spec.SourceRepos = []string{testRepo}
+	if isProjectUpToDate(p, r) {
+		t.Errorf("isProjectUpToDate(...): an empty list does not clear the list in ArgoCD")
+	}
+}
+
+func TestGenerateUpdateProjectOptionsPartiallyManaged(t *testing.T) {
+	current := &argocdv1alpha1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName, ResourceVersion: "3"},
+		Spec: argocdv1alpha1.AppProjectSpec{
+			Description: testDescription2,
+			SourceRepos: []string{testRepo},
+			Roles:       []argocdv1alpha1.ProjectRole{testRoleCI},
+			SyncWindows: argocdv1alpha1.SyncWindows{{Kind: "deny", Schedule: testSchedule, Duration: "1h"}},
+		},
+	}
+	cr := Project(
+		withExternalName(testProjectExternalName),
+		withSpec(testPartiallyManagedProject),
+	)
+
+	want := argocdv1alpha1.AppProjectSpec{
+		Description: testDescription2,
+		SourceRepos: testSourceRepos,
+		Destinations: []argocdv1alpha1.ApplicationDestination{
+			{Server: testServer, Namespace: testNamespace1},
+		},
+		Roles:       []argocdv1alpha1.ProjectRole{testRoleCI},
+		SyncWindows: argocdv1alpha1.SyncWindows{{Kind: "deny", Schedule: testSchedule, Duration: "1h"}},
+	}
+	got := generateUpdateProjectOptions(cr, current)
+	if diff := cmp.Diff(want, got.Project.Spec, cmpopts.IgnoreUnexported(argocdv1alpha1.ApplicationDestination{})); diff != "" {
+		t.Errorf("generateUpdateProjectOptions(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSyncWindows(t *testing.T) {
+	current := &argocdv1alpha1.AppProject{
+		ObjectMeta: metav1.ObjectMeta{Name: testProjectExternalName},
+		Spec: argocdv1alpha1.AppProjectSpec{
+			Description: testDescription,
+			SyncWindows: argocdv1alpha1.SyncWindows{
+				{Kind: "allow", Schedule: testSchedule, Duration: "1h", Applications: []string{"*"}},
+				{Kind: "deny", Schedule: testSchedule, Duration: "1h", Applications: []string{"app"}},
+			},
+		},
+	}
+
+	type want struct {
+		upToDate bool
+		windows  argocdv1alpha1.SyncWindows
+	}
+
+	cases := map[string]struct {
+		windows v1alpha1.SyncWindows
+		want    want
+	}{
+		"Unmanaged": {
+			windows: nil,
+			want:    want{upToDate: true, windows: current.Spec.SyncWindows},
+		},
+		"UpToDate": {
+			windows: testSyncWindows,
+			want:    want{upToDate: true, windows: current.Spec.SyncWindows},
+		},
+		"AllRemoved": {
+			windows: v1alpha1.SyncWindows{},
+			want:    want{upToDate: false, windows: argocdv1alpha1.SyncWindows{}},
+		},
+		"DenyWindowRemoved": {
+			windows: testSyncWindows[:1],
+			want:    want{upToDate: false, windows: current.Spec.SyncWindows[:1]},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Project(withSpec(v1alpha1.ProjectParameters{
+				Description: &testDescription,
+				SyncWindows: tc.windows,
+			}))
+			if got := isProjectUpToDate(&cr.Spec.ForProvider, current); got != tc.want.upToDate {
+				t.Errorf("isProjectUpToDate(...): want %t, got %t", tc.want.upToDate, got)
+			}
+			got := generateUpdateProjectOptions(cr, current).Project.Spec.SyncWindows
+			if diff := cmp.Diff(tc.want.windows, got); diff != "" {
+				t.Errorf("generateUpdateProjectOptions(...): -want sync windows, +got sync windows:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateProjectWarnings(t *testing.T) {
+	allow := &argocdv1alpha1.SyncWindow{Kind: "allow", Schedule: testSchedule, Duration: "1h", Applications: []string{"*"}}
+	deny := &argocdv1alpha1.SyncWindow{Kind: "deny", Schedule: testSchedule, Duration: "1h", Applications: []string{"app"}}
+	shadowed := `sync windows 0 and 1 overlap: allow window with schedule "10 1 * * *" is shadowed by the deny window`
+
+	cases := map[string]struct {
+		windows argocdv1alpha1.SyncWindows
+		want    []string
+	}{
+		"AllowShadowedByDeny": {
+			windows: argocdv1alpha1.SyncWindows{allow, deny},
+			want:    []string{shadowed},
+		},
+		"DenyListedFirst": {
+			windows: argocdv1alpha1.SyncWindows{deny, allow},
+			want:    []string{shadowed},
+		},
+		"Duplicates": {
+			windows: argocdv1alpha1.SyncWindows{allow, allow},
+			want:    []string{"sync windows 0 and 1 are duplicates"},
+		},
+		"OtherSchedule": {
+			windows: argocdv1alpha1.SyncWindows{allow, {Kind: "deny", Schedule: "0 22 * * *", Duration: "1h", Applications: []string{"app"}}},
+		},
+		"NoCommonTarget": {
+			windows: argocdv1alpha1.SyncWindows{{Kind: "allow", Schedule: testSchedule, Duration: "1h", Applications: []string{"other"}}, deny},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := generateProjectWarnings(&argocdv1alpha1.AppProject{Spec: argocdv1alpha1.AppProjectSpec{SyncWindows: tc.windows}})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("generateProjectWarnings(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsEqualSourceRepos(t *testing.T) {
+	cases := map[string]struct {
+		p, r    []string
+		ordered bool
+		want    bool
+	}{
+		"Reordered": {
+			p:    []string{testRepo, testMirrorRepo},
+			r:    []string{testMirrorRepo, testRepo},
+			want: true,
+		},
+		"Duplicates": {
+			p:    []string{testRepo, testMirrorRepo, testRepo},
+			r:    []string{testRepo, testMirrorRepo},
+			want: true,
+		},
+		"Missing": {
+			p:    []string{testRepo, testMirrorRepo},
+			r:    []string{testRepo},
+			want: false,
+		},
+		"OrderedSameOrder": {
+			p:       []string{testRepo, testMirrorRepo},
+			r:       []string{testRepo, testMirrorRepo},
+			ordered: true,
+			want:    true,
+		},
+		"OrderedReordered": {
+			p:       []string{testRepo, testMirrorRepo},
+			r:       []string{testMirrorRepo, testRepo},
+			ordered: true,
+			want:    false,
+		},
+		"OrderedDuplicates": {
+			p:       []string{testRepo, testMirrorRepo, testRepo},
+			r:       []string{testRepo, testMirrorRepo},
+			ordered: true,
+			want:    true,
+		},
+		"OrderedWildcardReordered": {
+			p:       []string{"*", testRepo},
+			r:       []string{testRepo, "*"},
+			ordered: true,
+			want:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isEqualSourceRepos(tc.p, tc.r, tc.ordered); got != tc.want {
+				t.Errorf("isEqualSourceRepos(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsEqualPolicies(t *testing.T) {
+	cases := map[string]struct {
+		p, r []string
+		want bool
+	}{
+		"Equal": {
+			p:    testDeployerPolicies,
+			r:    testDeployerPolicies,
+			want: true,
+		},
+		"Reordered": {
+			p:    testDeployerPolicies,
+			r:    []string{testDeployerPolicies[1], testDeployerPolicies[0]},
+			want: true,
+		},
+		"Whitespace": {
+			p:    []string{"p, proj:testproject:deployer, applications, sync, testproject/*, allow"},
+			r:    []string{"p,proj:testproject:deployer,applications,sync,testproject/*,allow"},
+			want: true,
+		},
+		"EffectChanged": {
+			p:    []string{"p, proj:testproject:deployer, applications, sync, testproject/*, allow"},
+			r:    []string{"p, proj:testproject:deployer, applications, sync, testproject/*, deny"},
+			want: false,
+		},
+		"Duplicate": {
+			p:    []string{testDeployerPolicies[0], testDeployerPolicies[0]},
+			r:    testDeployerPolicies,
+			want: false,
+		},
+		"Removed": {
+			p:    testDeployerPolicies[:1],
+			r:    testDeployerPolicies,
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isEqualPolicies(tc.p, tc.r); got != tc.want {
+				t.Errorf("isEqualPolicies(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizePolicy(t *testing.T) {
+	type want struct {
+		policy string
+		err    error
+	}
+
+	cases := map[string]struct {
+		policy string
+		want   want
+	}{
+		"Valid": {
+			policy: "p, proj:testproject:ci, applications, sync, testproject/*, allow",
+			want:   want{policy: "p, proj:testproject:ci, applications, sync, testproject/*, allow"},
+		},
+		"ValidKeptVerbatim": {
+			policy: "p,proj:testproject:ci,applications,sync,testproject/*,allow",
+			want:   want{policy: "p,proj:testproject:ci,applications,sync,testproject/*,allow"},
+		},
+		"ObjectWithoutProject": {
+			policy: "p,proj:testproject:ci,applications,sync,*,allow",
+			want:   want{policy: "p, proj:testproject:ci, applications, sync, testproject/*, allow"},
+		},
+		"SubjectOfOtherProject": {
+			policy: "p, proj:otherproject:ci, applications, sync, testproject/*, allow",
+			want: want{err: errors.Errorf(errFmtPolicySubject,
+				"p, proj:otherproject:ci, applications, sync, testproject/*, allow", "ci", "testproject", "ci")},
+		},
+		"ObjectOfOtherProject": {
+			policy: "p, proj:testproject:ci, applications, sync, otherproject/*, allow",
+			want: want{err: errors.Errorf(errFmtPolicyObject,
+				"p, proj:testproject:ci, applications, sync, otherproject/*, allow", "ci", "testproject")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := normalizePolicy(testProjectExternalName, "ci", tc.policy)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("normalizePolicy(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.policy, got); diff != "" {
+				t.Errorf("normalizePolicy(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateSyncWindows(t *testing.T) {
+	cases := map[string]struct {
+		timeZone *string
+		want     error
+	}{
+		"ServerDefault": {
+			timeZone: nil,
+		},
+		"ValidTimeZone": {
+			timeZone: ptr.To("Europe/Berlin"),
+		},
+		"InvalidTimeZone": {
+			timeZone: ptr.To("Mars/Olympus"),
+			want:     errors.Wrapf(errors.New("unknown time zone Mars/Olympus"), errFmtSyncWindowTimeZone, 1, "Mars/Olympus"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &v1alpha1.ProjectParameters{SyncWindows: v1alpha1.SyncWindows{
+				{Schedule: &testSchedule},
+				{Schedule: &testSchedule, TimeZone: tc.timeZone},
+			}}
+			err := validateSyncWindows(p)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("validateSyncWindows(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithResolvedWhitelists(t *testing.T) {
+	namespace := metav1.GroupKind{Kind: "Namespace"}
+	clusterRole := metav1.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}
+	crd := metav1.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+	shared := v1alpha1.GroupKindsConfigMapKeySelector{Name: "shared", Namespace: "argocd", Key: "cluster"}
+	team := v1alpha1.GroupKindsConfigMapKeySelector{Name: "team", Namespace: "argocd", Key: "cluster"}
+
+	configMaps := map[string]string{
+		"shared": "- group: \"\"\n  kind: Namespace\n- group: rbac.authorization.k8s.io\n  kind: ClusterRole\n",
+		"team":   "- group: apiextensions.k8s.io\n  kind: CustomResourceDefinition\n- group: \"\"\n  kind: Namespace\n",
+		"broken": "group: apiextensions.k8s.io",
+	}
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			v, ok := configMaps[key.Name]
+			if !ok {
+				return errBoom
+			}
+			obj.(*corev1.ConfigMap).Data = map[string]string{"cluster": v}
+			return nil
+		},
+	}
+
+	type want struct {
+		cluster   []metav1.GroupKind
+		namespace []metav1.GroupKind
+		err       error
+	}
+	cases := map[string]struct {
+		p    v1alpha1.ProjectParameters
+		want want
+	}{
+		"InlineOnly": {
+			p: v1alpha1.ProjectParameters{ClusterResourceWhitelist: []metav1.GroupKind{crd}},
+			want: want{
+				cluster: []metav1.GroupKind{crd},
+			},
+		},
+		"MergedInOrderWithoutDuplicates": {
+			p: v1alpha1.ProjectParameters{
+				ClusterResourceWhitelist:     []metav1.GroupKind{clusterRole},
+				ClusterResourceWhitelistFrom: []v1alpha1.GroupKindsConfigMapKeySelector{shared, team},
+			},
+			want: want{
+				cluster: []metav1.GroupKind{clusterRole, namespace, crd},
+			},
+		},
+		"NamespaceResources": {
+			p: v1alpha1.ProjectParameters{
+				NamespaceResourceWhitelistFrom: []v1alpha1.GroupKindsConfigMapKeySelector{team},
+			},
+			want: want{
+				namespace: []metav1.GroupKind{crd, namespace},
+			},
+		},
+		"ConfigMapNotFound": {
+			p: v1alpha1.ProjectParameters{
+				ClusterResourceWhitelistFrom: []v1alpha1.GroupKindsConfigMapKeySelector{{Name: "missing", Namespace: "argocd", Key: "cluster"}},
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtGetWhitelistConfigMap, "argocd", "missing"),
+			},
+		},
+		"KeyNotFound": {
+			p: v1alpha1.ProjectParameters{
+				ClusterResourceWhitelistFrom: []v1alpha1.GroupKindsConfigMapKeySelector{{Name: "shared", Namespace: "argocd", Key: "namespace"}},
+			},
+			want: want{
+				err: errors.Errorf(errFmtWhitelistKey, "argocd", "shared", "namespace"),
+			},
+		},
+		"NoList": {
+			p: v1alpha1.ProjectParameters{
+				ClusterResourceWhitelistFrom: []v1alpha1.GroupKindsConfigMapKeySelector{{Name: "broken", Namespace: "argocd", Key: "cluster"}},
+			},
+			want: want{
+				err: errors.Wrapf(errors.New("error unmarshaling JSON: while decoding JSON: json: cannot unmarshal object into Go value of type []v1.GroupKind"), errFmtParseWhitelist, "cluster", "argocd", "broken"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Project(withSpec(tc.p))
+			got, err := withResolvedWhitelists(context.Background(), kube, cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("withResolvedWhitelists(...): -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.cluster, got.Spec.ForProvider.ClusterResourceWhitelist); diff != "" {
+				t.Errorf("withResolvedWhitelists(...): -want cluster resources, +got cluster resources:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.namespace, got.Spec.ForProvider.NamespaceResourceWhitelist); diff != "" {
+				t.Errorf("withResolvedWhitelists(...): -want namespace resources, +got namespace resources:\n%s", diff)
+			}
+			if diff := cmp.Diff(Project(withSpec(tc.p)), cr); diff != "" {
+				t.Errorf("withResolvedWhitelists(...): must not modify the Project: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReferencingProjects(t *testing.T) {
+	referencing := Project(withSpec(v1alpha1.ProjectParameters{
+		NamespaceResourceWhitelistFrom: []v1alpha1.GroupKindsConfigMapKeySelector{{Name: "shared", Namespace: "argocd", Key: "namespace"}},
+	}))
+	referencing.SetName("referencing")
+	other := Project(withSpec(v1alpha1.ProjectParameters{
+		ClusterResourceWhitelistFrom: []v1alpha1.GroupKindsConfigMapKeySelector{{Name: "shared", Namespace: "team-a", Key: "cluster"}},
+	}))
+	other.SetName("other")
+
+	kube := &test.MockClient{
+		MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			obj.(*v1alpha1.ProjectList).Items = []v1alpha1.Project{*referencing, *other}
+			return nil
+		}),
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "argocd"}}
+
+	got := referencingProjects(kube, nil)(cm)
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "referencing"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("referencingProjects(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	type want struct {
+		sourceRepos []string
+		err         error
+	}
+
+	withRepository := func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		meta.SetExternalName(obj, testRepo)
+		return nil
+	}
+
+	cases := map[string]struct {
+		sourceRepos []string
+		kube        client.Client
+		want        want
+	}{
+		"WildcardKept": {
+			sourceRepos: []string{"*"},
+			kube: &test.MockClient{
+				MockGet:    withRepository,
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			want: want{sourceRepos: testSourceRepos},
+		},
+		"WithoutWildcard": {
+			kube: &test.MockClient{
+				MockGet:    withRepository,
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			want: want{sourceRepos: []string{testRepo}},
+		},
+		"AlreadyResolved": {
+			sourceRepos: testSourceRepos,
+			kube: &test.MockClient{
+				MockGet:    withRepository,
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			want: want{sourceRepos: testSourceRepos},
+		},
+		"ResolveError": {
+			sourceRepos: []string{"*"},
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			want: want{
+				sourceRepos: []string{"*"},
+				err:         errors.Wrap(errors.Wrap(errors.Wrap(errBoom, "cannot get referenced resource"), "mg.Spec.ForProvider.SourceRepos"), errResolveReferences),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := Project(withSpec(v1alpha1.ProjectParameters{
+				SourceRepos:     tc.sourceRepos,
+				SourceReposRefs: []xpv1.Reference{{Name: "provider-argocd"}},
+			}))
+			r := &referenceResolver{kube: tc.kube}
+			err := r.ResolveReferences(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sourceRepos, cr.Spec.ForProvider.SourceRepos); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
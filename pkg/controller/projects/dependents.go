@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projects
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	applicationsv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/applications/v1alpha1"
+	clusterv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/cluster/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+	repositoriesv1alpha1 "github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1"
+)
+
+const (
+	errListDependents = "cannot list the managed resources using the Argocd Project"
+	errFmtDependents  = "cannot delete Argocd Project while it is used by %s"
+)
+
+// dependents returns the Applications, Clusters and Repositories managed by
+// this provider that are in the supplied Project of the same ArgoCD, i.e. that
+// use the same ProviderConfig. Resources being deleted are returned until they
+// are gone, so that the Project outlives everything it contains.
+func dependents(ctx context.Context, kube client.Client, cr *v1alpha1.Project) ([]string, error) {
+	proj := projectName(cr)
+	pc := providerConfigName(cr)
+
+	var res []string
+	apps := &applicationsv1alpha1.ApplicationList{}
+	if err := kube.List(ctx, apps); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for i := range apps.Items {
+		a := &apps.Items[i]
+		if a.Spec.ForProvider.Project == proj && providerConfigName(a) == pc {
+			res = append(res, applicationsv1alpha1.ApplicationKind+" "+a.GetName())
+		}
+	}
+
+	clusters := &clusterv1alpha1.ClusterList{}
+	if err := kube.List(ctx, clusters); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for i := range clusters.Items {
+		c := &clusters.Items[i]
+		if p := c.Spec.ForProvider.Project; p != nil && *p == proj && providerConfigName(c) == pc {
+			res = append(res, clusterv1alpha1.ClusterKind+" "+c.GetName())
+		}
+	}
+
+	repos := &repositoriesv1alpha1.RepositoryList{}
+	if err := kube.List(ctx, repos); err != nil {
+		return nil, errors.Wrap(err, errListDependents)
+	}
+	for i := range repos.Items {
+		r := &repos.Items[i]
+		if p := r.Spec.ForProvider.Project; p != nil && *p == proj && providerConfigName(r) == pc {
+			res = append(res, repositoriesv1alpha1.RepositoryKind+" "+r.GetName())
+		}
+	}
+	return res, nil
+}
+
+// errDependents returns an error listing the supplied dependents, or nil if
+// there are none.
+func errDependents(deps []string) error {
+	if len(deps) == 0 {
+		return nil
+	}
+	return errors.Errorf(errFmtDependents, strings.Join(deps, ", "))
+}
+
+func providerConfigName(mg resource.Managed) string {
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		return ref.Name
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projects
+
+import (
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+)
+
+// projectFields is a set of fields of an AppProject. A nil set contains all
+// fields.
+type projectFields map[v1alpha1.ProjectField]bool
+
+// managedFields returns the fields of the AppProject managed by the supplied
+// parameters.
+func managedFields(p *v1alpha1.ProjectParameters) projectFields {
+	if len(p.ManagedFields) == 0 {
+		return nil
+	}
+	m := make(projectFields, len(p.ManagedFields))
+	for _, f := range p.ManagedFields {
+		m[f] = true
+	}
+	return m
+}
+
+func (m projectFields) has(f v1alpha1.ProjectField) bool {
+	return m == nil || m[f]
+}
+
+// keepUnmanagedFields sets the fields of the desired AppProject spec that are
+// not managed to their current value, so that an update leaves them as they
+// are.
+func keepUnmanagedFields(desired, current *argocdv1alpha1.AppProjectSpec, m projectFields) { // nolint:gocyclo // checking all fields can't be reduced
+	if !m.has(v1alpha1.ProjectFieldSourceRepos) {
+		desired.SourceRepos = current.SourceRepos
+	}
+	if !m.has(v1alpha1.ProjectFieldDestinations) {
+		desired.Destinations = current.Destinations
+	}
+	if !m.has(v1alpha1.ProjectFieldDescription) {
+		desired.Description = current.Description
+	}
+	if !m.has(v1alpha1.ProjectFieldRoles) {
+		desired.Roles = current.Roles
+	}
+	if !m.has(v1alpha1.ProjectFieldClusterResourceWhitelist) {
+		desired.ClusterResourceWhitelist = current.ClusterResourceWhitelist
+	}
+	if !m.has(v1alpha1.ProjectFieldNamespaceResourceBlacklist) {
+		desired.NamespaceResourceBlacklist = current.NamespaceResourceBlacklist
+	}
+	if !m.has(v1alpha1.ProjectFieldOrphanedResources) {
+		desired.OrphanedResources = current.OrphanedResources
+	}
+	if !m.has(v1alpha1.ProjectFieldSyncWindows) {
+		desired.SyncWindows = current.SyncWindows
+	}
+	if !m.has(v1alpha1.ProjectFieldNamespaceResourceWhitelist) {
+		desired.NamespaceResourceWhitelist = current.NamespaceResourceWhitelist
+	}
+	if !m.has(v1alpha1.ProjectFieldSignatureKeys) {
+		desired.SignatureKeys = current.SignatureKeys
+	}
+	if !m.has(v1alpha1.ProjectFieldClusterResourceBlacklist) {
+		desired.ClusterResourceBlacklist = current.ClusterResourceBlacklist
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projects
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+)
+
+const (
+	errFmtPolicySubject = "policy %q of role %q must have subject proj:%s:%s"
+	errFmtPolicyObject  = "policy %q of role %q must have an object in project %s"
+)
+
+// projectName returns the name of the AppProject of the supplied Project. It
+// is the external name once the AppProject was created.
+func projectName(cr *v1alpha1.Project) string {
+	if n := meta.GetExternalName(cr); n != "" {
+		return n
+	}
+	return cr.Name
+}
+
+// withNormalizedPolicies returns a copy of the supplied Project whose role
+// policies are normalized by normalizePolicy.
+func withNormalizedPolicies(cr *v1alpha1.Project) (*v1alpha1.Project, error) {
+	out := cr.DeepCopy()
+	proj := projectName(cr)
+	for i, r := range out.Spec.ForProvider.Roles {
+		for j, p := range r.Policies {
+			np, err := normalizePolicy(proj, r.Name, p)
+			if err != nil {
+				return nil, err
+			}
+			out.Spec.ForProvider.Roles[i].Policies[j] = np
+		}
+	}
+	return out, nil
+}
+
+// isEqualPolicies compares the policies of a role as an unordered list. Each
+// policy is compared field by field, so that a changed effect is detected
+// however the policy is formatted, while the order of the policies and the
+// whitespace around their fields are ignored.
+func isEqualPolicies(p, r []string) bool {
+	if len(p) != len(r) {
+		return false
+	}
+	count := make(map[string]int, len(p))
+	for _, policy := range p {
+		count[policyKey(policy)]++
+	}
+	for _, policy := range r {
+		k := policyKey(policy)
+		if count[k] == 0 {
+			return false
+		}
+		count[k]--
+	}
+	return true
+}
+
+// policyKey returns the fields of a policy, like subject, resource, action,
+// object and effect, without surrounding whitespace.
+func policyKey(policy string) string {
+	fields := strings.Split(policy, ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return strings.Join(fields, ",")
+}
+
+// normalizePolicy validates a policy of a project role against the project.
+// ArgoCD ignores policies of other projects without an error, so policies
+// whose subject or object name another project are rejected. An object
+// without a project, like *, is scoped to the project. Policies that need no
+// change are returned as they are, so that they are compared verbatim.
+func normalizePolicy(proj, role, policy string) (string, error) {
+	fields := strings.Split(policy, ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	// Only permission policies are checked, ArgoCD rejects anything else.
+	if len(fields) != 6 || fields[0] != "p" {
+		return policy, nil
+	}
+	if fields[1] != "proj:"+proj+":"+role {
+		return "", errors.Errorf(errFmtPolicySubject, policy, role, proj, role)
+	}
+	obj := fields[4]
+	if !strings.Contains(obj, "/") {
+		fields[4] = proj + "/" + obj
+		return strings.Join(fields, ", "), nil
+	}
+	if !strings.HasPrefix(obj, proj+"/") {
+		return "", errors.Errorf(errFmtPolicyObject, policy, role, proj)
+	}
+	return policy, nil
+}
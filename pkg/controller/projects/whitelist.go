@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projects
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/projects/v1alpha1"
+)
+
+const (
+	errFmtGetWhitelistConfigMap = "cannot get resource whitelist ConfigMap %s/%s"
+	errFmtWhitelistKey          = "resource whitelist ConfigMap %s/%s has no key %q"
+	errFmtParseWhitelist        = "cannot parse key %q of resource whitelist ConfigMap %s/%s"
+)
+
+// withResolvedWhitelists returns a copy of the supplied Project whose resource
+// whitelists contain the resources of the referenced ConfigMaps. The inline
+// resources come first, followed by those of the ConfigMaps in the order they
+// are referenced. Resources that are already listed are skipped, so that the
+// result does not depend on how often a resource is shared.
+func withResolvedWhitelists(ctx context.Context, kube client.Client, cr *v1alpha1.Project) (*v1alpha1.Project, error) {
+	out := cr.DeepCopy()
+	p := &out.Spec.ForProvider
+
+	var err error
+	if p.ClusterResourceWhitelist, err = resolveWhitelist(ctx, kube, p.ClusterResourceWhitelist, p.ClusterResourceWhitelistFrom); err != nil {
+		return nil, err
+	}
+	if p.NamespaceResourceWhitelist, err = resolveWhitelist(ctx, kube, p.NamespaceResourceWhitelist, p.NamespaceResourceWhitelistFrom); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func resolveWhitelist(ctx context.Context, kube client.Client, inline []metav1.GroupKind, from []v1alpha1.GroupKindsConfigMapKeySelector) ([]metav1.GroupKind, error) {
+	if len(from) == 0 {
+		return inline, nil
+	}
+
+	res := make([]metav1.GroupKind, 0, len(inline))
+	seen := map[metav1.GroupKind]bool{}
+	add := func(gks []metav1.GroupKind) {
+		for _, gk := range gks {
+			if !seen[gk] {
+				seen[gk] = true
+				res = append(res, gk)
+			}
+		}
+	}
+
+	add(inline)
+	for _, sel := range from {
+		gks, err := getGroupKinds(ctx, kube, sel)
+		if err != nil {
+			return nil, err
+		}
+		add(gks)
+	}
+	return res, nil
+}
+
+func getGroupKinds(ctx context.Context, kube client.Client, sel v1alpha1.GroupKindsConfigMapKeySelector) ([]metav1.GroupKind, error) {
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: sel.Namespace, Name: sel.Name}, cm); err != nil {
+		return nil, errors.Wrapf(err, errFmtGetWhitelistConfigMap, sel.Namespace, sel.Name)
+	}
+	v, ok := cm.Data[sel.Key]
+	if !ok {
+		return nil, errors.Errorf(errFmtWhitelistKey, sel.Namespace, sel.Name, sel.Key)
+	}
+	var gks []metav1.GroupKind
+	if err := yaml.Unmarshal([]byte(v), &gks); err != nil {
+		return nil, errors.Wrapf(err, errFmtParseWhitelist, sel.Key, sel.Namespace, sel.Name)
+	}
+	return gks, nil
+}
+
+// referencingProjects returns a handler.MapFunc that maps a ConfigMap to the
+// Projects selected by the supplied shard selector whose resource whitelists
+// reference it, so that they are reconciled when it changes. A nil selector
+// selects all Projects.
+func referencingProjects(kube client.Client, sel labels.Selector) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		var opts []client.ListOption
+		if sel != nil {
+			opts = append(opts, client.MatchingLabelsSelector{Selector: sel})
+		}
+		l := &v1alpha1.ProjectList{}
+		if err := kube.List(context.Background(), l, opts...); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for i := range l.Items {
+			if referencesConfigMap(&l.Items[i].Spec.ForProvider, o.GetNamespace(), o.GetName()) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: l.Items[i].GetName()}})
+			}
+		}
+		return reqs
+	}
+}
+
+// referencesConfigMap returns true if a resource whitelist of the supplied
+// Project references the ConfigMap with the supplied namespace and name.
+func referencesConfigMap(p *v1alpha1.ProjectParameters, namespace, name string) bool {
+	for _, from := range [][]v1alpha1.GroupKindsConfigMapKeySelector{p.ClusterResourceWhitelistFrom, p.NamespaceResourceWhitelistFrom} {
+		for _, sel := range from {
+			if sel.Namespace == namespace && sel.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
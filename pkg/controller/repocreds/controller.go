@@ -0,0 +1,281 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repocreds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/repocreds/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	repocredsclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/repocreds"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/options"
+	"github.com/crossplane-contrib/provider-argocd/pkg/jitter"
+	"github.com/crossplane-contrib/provider-argocd/pkg/metrics"
+	"github.com/crossplane-contrib/provider-argocd/pkg/shard"
+	"github.com/crossplane-contrib/provider-argocd/pkg/tracing"
+)
+
+const (
+	errNotRepositoryCredentials = "managed resource is not a Argocd repository credentials custom resource"
+	errListFailed               = "cannot list Argocd repository credentials"
+	errCreateFailed             = "cannot create Argocd repository credentials"
+	errUpdateFailed             = "cannot update Argocd repository credentials"
+	errDeleteFailed             = "cannot delete Argocd repository credentials"
+	errGetSecretFailed          = "cannot get Kubernetes secret"
+	errFmtKeyNotFound           = "key %s is not found in referenced Kubernetes secret"
+	errHashFailed               = "cannot hash Argocd repository credentials"
+)
+
+// SetupRepositoryCredentials adds a controller that reconciles repository
+// credential templates.
+func SetupRepositoryCredentials(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.RepositoryCredentialsKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		Watches(&source.Kind{Type: &v1alpha1.RepositoryCredentials{}}, jitter.NewEventHandler(o.Jitter.For(v1alpha1.RepositoryCredentialsKind)), builder.WithPredicates(shard.NewPredicate(o.ShardSelector))).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RepositoryCredentialsGroupVersionKind),
+			managed.WithExternalConnecter(tracing.NewConnecter(v1alpha1.RepositoryCredentialsKind, metrics.NewDriftConnecter(v1alpha1.RepositoryCredentialsKind, &connector{kube: mgr.GetClient(), pool: o.Pool, newArgocdClientFn: repocredsclient.NewRepoCredsServiceClient}))),
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type connector struct {
+	kube              client.Client
+	pool              *clients.Pool
+	newArgocdClientFn func(c *clients.PooledClient) (repocreds.RepoCredsServiceClient, error)
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCredentials)
+	if !ok {
+		return nil, errors.New(errNotRepositoryCredentials)
+	}
+	pc, err := c.pool.Connect(ctx, c.kube, cr)
+	if err != nil {
+		return nil, err
+	}
+	argocdClient, err := c.newArgocdClientFn(pc)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.kube, client: argocdClient}, nil
+}
+
+type external struct {
+	kube   client.Client
+	client repocredsclient.ServiceClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCredentials)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepositoryCredentials)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	// The URL prefix is the identity of a credential template. ArgoCD lists
+	// all templates regardless of the query.
+	list, err := e.client.ListRepositoryCredentials(ctx, &repocreds.RepoCredsQuery{Url: meta.GetExternalName(cr)})
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(clients.HandleMaintenance(cr, err), errListFailed)
+	}
+	var observed *argocdv1alpha1.RepoCreds
+	for i := range list.Items {
+		if list.Items[i].URL == meta.GetExternalName(cr) {
+			observed = &list.Items[i]
+			break
+		}
+	}
+	if observed == nil {
+		return managed.ExternalObservation{}, nil
+	}
+
+	hash, err := e.configHash(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isRepositoryCredentialsUpToDate(&cr.Spec.ForProvider, observed) && hash == cr.Status.AtProvider.ConfigHash,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCredentials)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepositoryCredentials)
+	}
+
+	creds, err := e.generateRepoCreds(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	hash, err := e.configHash(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if _, err := e.client.CreateRepositoryCredentials(ctx, &repocreds.RepoCredsCreateRequest{Creds: creds}); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+
+	meta.SetExternalName(cr, cr.Spec.ForProvider.URL)
+	cr.Status.AtProvider.ConfigHash = hash
+
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+	}, nil
+}
+
+// Update writes the credentials in place, so that e.g. a rotated password
+// never removes the credentials of the repositories in between.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RepositoryCredentials)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepositoryCredentials)
+	}
+
+	creds, err := e.generateRepoCreds(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	creds.URL = meta.GetExternalName(cr)
+	hash, err := e.configHash(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if _, err := e.client.UpdateRepositoryCredentials(ctx, &repocreds.RepoCredsUpdateRequest{Creds: creds}); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+
+	cr.Status.AtProvider.ConfigHash = hash
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RepositoryCredentials)
+	if !ok {
+		return errors.New(errNotRepositoryCredentials)
+	}
+
+	_, err := e.client.DeleteRepositoryCredentials(ctx, &repocreds.RepoCredsDeleteRequest{Url: meta.GetExternalName(cr)})
+	return errors.Wrap(err, errDeleteFailed)
+}
+
+// isRepositoryCredentialsUpToDate compares the parameters ArgoCD returns for
+// a credential template. All others are compared by their hash.
+func isRepositoryCredentialsUpToDate(p *v1alpha1.RepositoryCredentialsParameters, r *argocdv1alpha1.RepoCreds) bool {
+	return clients.StringValue(p.Username) == r.Username
+}
+
+// generateRepoCreds returns the credential template of the supplied
+// parameters, including the values of the referenced secrets.
+func (e *external) generateRepoCreds(ctx context.Context, p *v1alpha1.RepositoryCredentialsParameters) (*argocdv1alpha1.RepoCreds, error) {
+	creds := &argocdv1alpha1.RepoCreds{
+		URL:       p.URL,
+		Type:      clients.StringValue(p.Type),
+		Username:  clients.StringValue(p.Username),
+		EnableOCI: clients.BoolValue(p.EnableOCI),
+	}
+
+	for _, s := range []struct {
+		ref *v1alpha1.SecretReference
+		to  *string
+	}{
+		{ref: p.PasswordRef, to: &creds.Password},
+		{ref: p.SSHPrivateKeyRef, to: &creds.SSHPrivateKey},
+		{ref: p.TLSClientCertDataRef, to: &creds.TLSClientCertData},
+		{ref: p.TLSClientCertKeyRef, to: &creds.TLSClientCertKey},
+	} {
+		if s.ref == nil {
+			continue
+		}
+		sc, err := e.getSecret(ctx, s.ref)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := sc.Data[s.ref.Key]
+		if !ok {
+			return nil, errors.Errorf(errFmtKeyNotFound, s.ref.Key)
+		}
+		*s.to = string(v)
+	}
+	return creds, nil
+}
+
+// configHash returns a hash of the supplied parameters and of the resource
+// versions of the secrets they reference. ArgoCD does not return the
+// credentials, so a change of the hash is the only way to tell that they
+// need to be written again. The hash never depends on the secret values.
+func (e *external) configHash(ctx context.Context, p *v1alpha1.RepositoryCredentialsParameters) (string, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return "", errors.Wrap(err, errHashFailed)
+	}
+	h := sha256.New()
+	_, _ = h.Write(b)
+	for _, ref := range []*v1alpha1.SecretReference{p.PasswordRef, p.SSHPrivateKeyRef, p.TLSClientCertDataRef, p.TLSClientCertKeyRef} {
+		if ref == nil {
+			continue
+		}
+		sc, err := e.getSecret(ctx, ref)
+		if err != nil {
+			return "", err
+		}
+		_, _ = h.Write([]byte("\n" + sc.GetResourceVersion()))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (e *external) getSecret(ctx context.Context, ref *v1alpha1.SecretReference) (*corev1.Secret, error) {
+	sc := &corev1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, sc); err != nil {
+		return nil, errors.Wrap(err, errGetSecretFailed)
+	}
+	return sc, nil
+}
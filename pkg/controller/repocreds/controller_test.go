@@ -0,0 +1,280 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repocreds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/repocreds/v1alpha1"
+	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/repocreds"
+)
+
+var (
+	errBoom         = errors.New("boom")
+	testURL         = "https://github.com/example-org/"
+	testUsername    = "example-user"
+	testPassword    = "s3cr3t"
+	testPasswordRef = v1alpha1.SecretReference{Name: "example-org", Namespace: "crossplane-system", Key: "token"}
+)
+
+type mockModifier func(*mockclient.MockServiceClient)
+
+func withMockClient(t *testing.T, mod mockModifier) *mockclient.MockServiceClient {
+	ctrl := gomock.NewController(t)
+	mock := mockclient.NewMockServiceClient(ctrl)
+	mod(mock)
+	return mock
+}
+
+// withSecret returns a client that serves the password secret at the given
+// resource version.
+func withSecret(resourceVersion string) client.Client {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			s := obj.(*corev1.Secret)
+			s.SetResourceVersion(resourceVersion)
+			s.Data = map[string][]byte{testPasswordRef.Key: []byte(testPassword)}
+			return nil
+		}),
+	}
+}
+
+func testParameters() v1alpha1.RepositoryCredentialsParameters {
+	return v1alpha1.RepositoryCredentialsParameters{
+		URL:         testURL,
+		Username:    &testUsername,
+		PasswordRef: &testPasswordRef,
+	}
+}
+
+func RepositoryCredentials(m ...func(*v1alpha1.RepositoryCredentials)) *v1alpha1.RepositoryCredentials {
+	cr := &v1alpha1.RepositoryCredentials{Spec: v1alpha1.RepositoryCredentialsSpec{ForProvider: testParameters()}}
+	meta.SetExternalName(cr, testURL)
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// withAppliedHash records the hash of the credentials as of the given secret
+// version as written to ArgoCD.
+func withAppliedHash(t *testing.T, resourceVersion string) func(*v1alpha1.RepositoryCredentials) {
+	return func(cr *v1alpha1.RepositoryCredentials) {
+		e := &external{kube: withSecret(resourceVersion)}
+		h, err := e.configHash(context.Background(), &cr.Spec.ForProvider)
+		if err != nil {
+			t.Fatalf("configHash(...): %v", err)
+		}
+		cr.Status.AtProvider.ConfigHash = h
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		result managed.ExternalObservation
+		err    error
+	}
+
+	listing := func(items ...argocdv1alpha1.RepoCreds) mockModifier {
+		return func(mcs *mockclient.MockServiceClient) {
+			mcs.EXPECT().ListRepositoryCredentials(context.Background(), &repocreds.RepoCredsQuery{Url: testURL}).
+				Return(&argocdv1alpha1.RepoCredsList{Items: items}, nil)
+		}
+	}
+
+	cases := map[string]struct {
+		client mockModifier
+		cr     *v1alpha1.RepositoryCredentials
+		kubeRV string
+		want   want
+	}{
+		"NoExternalName": {
+			client: func(_ *mockclient.MockServiceClient) {},
+			cr:     RepositoryCredentials(func(cr *v1alpha1.RepositoryCredentials) { meta.SetExternalName(cr, "") }),
+			want:   want{result: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NotFound": {
+			client: listing(argocdv1alpha1.RepoCreds{URL: "https://github.com/other-org/", Username: testUsername}),
+			cr:     RepositoryCredentials(),
+			want:   want{result: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"ListFailed": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().ListRepositoryCredentials(gomock.Any(), gomock.Any()).Return(nil, errBoom)
+			},
+			cr:   RepositoryCredentials(),
+			want: want{err: errors.Wrap(errBoom, errListFailed)},
+		},
+		"UpToDate": {
+			client: listing(argocdv1alpha1.RepoCreds{URL: testURL, Username: testUsername}),
+			cr:     RepositoryCredentials(withAppliedHash(t, "1")),
+			kubeRV: "1",
+			want:   want{result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"UsernameChanged": {
+			client: listing(argocdv1alpha1.RepoCreds{URL: testURL, Username: "someone-else"}),
+			cr:     RepositoryCredentials(withAppliedHash(t, "1")),
+			kubeRV: "1",
+			want:   want{result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"PasswordRotated": {
+			client: listing(argocdv1alpha1.RepoCreds{URL: testURL, Username: testUsername}),
+			cr:     RepositoryCredentials(withAppliedHash(t, "1")),
+			kubeRV: "2",
+			want:   want{result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"ParametersChanged": {
+			client: listing(argocdv1alpha1.RepoCreds{URL: testURL, Username: testUsername}),
+			cr: RepositoryCredentials(withAppliedHash(t, "1"), func(cr *v1alpha1.RepositoryCredentials) {
+				cr.Spec.ForProvider.EnableOCI = ptr.To(true)
+			}),
+			kubeRV: "1",
+			want:   want{result: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: withSecret(tc.kubeRV), client: withMockClient(t, tc.client)}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if o.ResourceExists {
+				if diff := cmp.Diff(xpv1.Available(), tc.cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+					t.Errorf("Observe(...): -want condition, +got condition:\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cr := RepositoryCredentials(func(cr *v1alpha1.RepositoryCredentials) { meta.SetExternalName(cr, "") })
+	mcs := withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+		mcs.EXPECT().CreateRepositoryCredentials(context.Background(), &repocreds.RepoCredsCreateRequest{
+			Creds: &argocdv1alpha1.RepoCreds{URL: testURL, Username: testUsername, Password: testPassword},
+		}).Return(&argocdv1alpha1.RepoCreds{URL: testURL, Username: testUsername}, nil)
+	})
+
+	e := &external{kube: withSecret("1"), client: mcs}
+	got, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalCreation{ExternalNameAssigned: true}, got); diff != "" {
+		t.Errorf("Create(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(testURL, meta.GetExternalName(cr)); diff != "" {
+		t.Errorf("Create(...): -want external name, +got external name:\n%s", diff)
+	}
+	if diff := cmp.Diff(RepositoryCredentials(withAppliedHash(t, "1")).Status, cr.Status); diff != "" {
+		t.Errorf("Create(...): -want status, +got status:\n%s", diff)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		hashRV string
+		err    error
+	}
+
+	cases := map[string]struct {
+		client mockModifier
+		want   want
+	}{
+		"PasswordRotated": {
+			// The rotated password is written in place. Neither Delete nor
+			// Create are expected.
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().UpdateRepositoryCredentials(context.Background(), &repocreds.RepoCredsUpdateRequest{
+					Creds: &argocdv1alpha1.RepoCreds{URL: testURL, Username: testUsername, Password: testPassword},
+				}).Return(&argocdv1alpha1.RepoCreds{URL: testURL, Username: testUsername}, nil)
+			},
+			want: want{hashRV: "2"},
+		},
+		"UpdateFailed": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().UpdateRepositoryCredentials(gomock.Any(), gomock.Any()).Return(nil, errBoom)
+			},
+			want: want{hashRV: "1", err: errors.Wrap(errBoom, errUpdateFailed)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := RepositoryCredentials(withAppliedHash(t, "1"))
+			e := &external{kube: withSecret("2"), client: withMockClient(t, tc.client)}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			// The hash is only recorded once the credentials were written.
+			if diff := cmp.Diff(RepositoryCredentials(withAppliedHash(t, tc.want.hashRV)).Status, cr.Status); diff != "" {
+				t.Errorf("Update(...): -want status, +got status:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		client mockModifier
+		want   error
+	}{
+		"Successful": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().DeleteRepositoryCredentials(context.Background(), &repocreds.RepoCredsDeleteRequest{Url: testURL}).
+					Return(&repocreds.RepoCredsResponse{}, nil)
+			},
+		},
+		"DeleteFailed": {
+			client: func(mcs *mockclient.MockServiceClient) {
+				mcs.EXPECT().DeleteRepositoryCredentials(gomock.Any(), gomock.Any()).Return(nil, errBoom)
+			},
+			want: errors.Wrap(errBoom, errDeleteFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: withMockClient(t, tc.client)}
+			err := e.Delete(context.Background(), RepositoryCredentials())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/google/go-cmp/cmp"
@@ -28,7 +27,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -40,6 +41,11 @@ import (
 	"github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
 	"github.com/crossplane-contrib/provider-argocd/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-argocd/pkg/controller/options"
+	"github.com/crossplane-contrib/provider-argocd/pkg/jitter"
+	"github.com/crossplane-contrib/provider-argocd/pkg/metrics"
+	"github.com/crossplane-contrib/provider-argocd/pkg/shard"
+	"github.com/crossplane-contrib/provider-argocd/pkg/tracing"
 )
 
 const (
@@ -54,15 +60,15 @@ const (
 )
 
 // SetupRepository adds a controller that reconciles repositories.
-func SetupRepository(mgr ctrl.Manager, l logging.Logger) error {
+func SetupRepository(mgr ctrl.Manager, l logging.Logger, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.RepositoryKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.Repository{}).
+		Watches(&source.Kind{Type: &v1alpha1.Repository{}}, jitter.NewEventHandler(o.Jitter.For(v1alpha1.RepositoryKind)), builder.WithPredicates(shard.NewPredicate(o.ShardSelector))).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.RepositoryGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newArgocdClientFn: repositories.NewRepositoryServiceClient}),
+			managed.WithExternalConnecter(tracing.NewConnecter(v1alpha1.RepositoryKind, metrics.NewDriftConnecter(v1alpha1.RepositoryKind, &connector{kube: mgr.GetClient(), pool: o.Pool, lateInitialize: o.LateInitialize, newArgocdClientFn: repositories.NewRepositoryServiceClient}))),
 			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
@@ -70,7 +76,9 @@ func SetupRepository(mgr ctrl.Manager, l logging.Logger) error {
 
 type connector struct {
 	kube              client.Client
-	newArgocdClientFn func(clientOpts *apiclient.ClientOptions) repository.RepositoryServiceClient
+	pool              *clients.Pool
+	newArgocdClientFn func(c *clients.PooledClient) (repository.RepositoryServiceClient, error)
+	lateInitialize    bool
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -78,16 +86,21 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if !ok {
 		return nil, errors.New(errNotRepository)
 	}
-	cfg, err := clients.GetConfig(ctx, c.kube, cr)
+	pc, err := c.pool.Connect(ctx, c.kube, cr)
 	if err != nil {
 		return nil, err
 	}
-	return &external{kube: c.kube, client: c.newArgocdClientFn(cfg)}, nil
+	argocdClient, err := c.newArgocdClientFn(pc)
+	if err != nil {
+		return nil, err
+	}
+	return &external{kube: c.kube, client: argocdClient, lateInitialize: c.lateInitialize}, nil
 }
 
 type external struct {
-	kube   client.Client
-	client repositories.RepositoryServiceClient
+	kube           client.Client
+	client         repositories.RepositoryServiceClient
+	lateInitialize bool
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -111,7 +124,7 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	repository := &argocdv1alpha1.Repository{}
 	repositoryList, err := e.client.ListRepositories(ctx, &repoQuery)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errGetFailed)
+		return managed.ExternalObservation{}, errors.Wrap(clients.HandleMaintenance(cr, err), errGetFailed)
 	}
 	if repositoryList.Items != nil {
 		for _, r := range repositoryList.Items {
@@ -126,14 +139,20 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	current := cr.Spec.ForProvider.DeepCopy()
-	lateInitializeRepository(&cr.Spec.ForProvider, repository)
+	// Without late initialization, unset fields are compared with the values
+	// ArgoCD defaulted, but these are never written to the spec.
+	desired := &cr.Spec.ForProvider
+	if !clients.ShouldLateInitialize(cr, e.lateInitialize) {
+		desired = desired.DeepCopy()
+	}
+	lateInitializeRepository(desired, repository)
 
 	cr.Status.AtProvider = generateRepositoryObservation(repository)
 	cr.Status.SetConditions(xpv1.Available())
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceUpToDate:        isRepositoryUpToDate(&cr.Spec.ForProvider, repository),
+		ResourceUpToDate:        isRepositoryUpToDate(desired, repository),
 		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
 	}, nil
 }
@@ -320,6 +339,9 @@ func generateCreateRepositoryOptions(p *v1alpha1.RepositoryParameters) *reposito
 	if p.Name != nil {
 		repo.Name = *p.Name
 	}
+	if p.Project != nil {
+		repo.Project = *p.Project
+	}
 	if p.EnableOCI != nil {
 		repo.EnableOCI = *p.EnableOCI
 	}
@@ -363,6 +385,9 @@ func generateUpdateRepositoryOptions(p *v1alpha1.RepositoryParameters) *reposito
 	if p.Name != nil {
 		repo.Name = *p.Name
 	}
+	if p.Project != nil {
+		repo.Project = *p.Project
+	}
 	if p.GithubAppID != nil {
 		repo.GithubAppId = *p.GithubAppID
 	}
@@ -396,6 +421,9 @@ func isRepositoryUpToDate(p *v1alpha1.RepositoryParameters, r *argocdv1alpha1.Re
 	if !cmp.Equal(p.Name, clients.StringToPtr(r.Name)) {
 		return false
 	}
+	if clients.StringValue(p.Project) != r.Project {
+		return false
+	}
 	if !clients.IsBoolEqualToBoolPtr(p.EnableOCI, r.EnableOCI) {
 		return false
 	}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"testing"
+
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/repositories/v1alpha1"
+)
+
+var (
+	testRepo    = "https://github.com/crossplane-contrib/provider-argocd"
+	testProject = "team-a"
+)
+
+func TestIsRepositoryUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		p    *v1alpha1.RepositoryParameters
+		r    *argocdv1alpha1.Repository
+		want bool
+	}{
+		"GlobalUpToDate": {
+			p:    &v1alpha1.RepositoryParameters{Repo: testRepo, Type: ptr.To("git")},
+			r:    &argocdv1alpha1.Repository{Repo: testRepo, Type: "git"},
+			want: true,
+		},
+		"ProjectUpToDate": {
+			p:    &v1alpha1.RepositoryParameters{Repo: testRepo, Type: ptr.To("git"), Project: &testProject},
+			r:    &argocdv1alpha1.Repository{Repo: testRepo, Type: "git", Project: testProject},
+			want: true,
+		},
+		"ProjectChanged": {
+			p:    &v1alpha1.RepositoryParameters{Repo: testRepo, Type: ptr.To("git"), Project: &testProject},
+			r:    &argocdv1alpha1.Repository{Repo: testRepo, Type: "git", Project: "team-b"},
+			want: false,
+		},
+		"ProjectRemoved": {
+			p:    &v1alpha1.RepositoryParameters{Repo: testRepo, Type: ptr.To("git")},
+			r:    &argocdv1alpha1.Repository{Repo: testRepo, Type: "git", Project: testProject},
+			want: false,
+		},
+		"TypeChanged": {
+			p:    &v1alpha1.RepositoryParameters{Repo: testRepo, Type: ptr.To("helm")},
+			r:    &argocdv1alpha1.Repository{Repo: testRepo, Type: "git"},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isRepositoryUpToDate(tc.p, tc.r); got != tc.want {
+				t.Errorf("isRepositoryUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateRepositoryOptionsProject(t *testing.T) {
+	p := &v1alpha1.RepositoryParameters{
+		Repo:           testRepo,
+		Project:        &testProject,
+		Insecure:       ptr.To(false),
+		EnableLFS:      ptr.To(false),
+		EnableOCI:      ptr.To(false),
+		InheritedCreds: ptr.To(false),
+	}
+
+	if diff := cmp.Diff(testProject, generateCreateRepositoryOptions(p).Repo.Project); diff != "" {
+		t.Errorf("generateCreateRepositoryOptions(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(testProject, generateUpdateRepositoryOptions(p).Repo.Project); diff != "" {
+		t.Errorf("generateUpdateRepositoryOptions(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jitter spreads the first reconciles of new managed resources.
+package jitter
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const errFmtParseMaxByKind = "cannot parse jitter %q of kind %s"
+
+// Options configure the jitter of the first reconcile of new resources.
+type Options struct {
+	// Max is the maximum delay of the first reconcile of a resource of any
+	// kind. Zero disables the jitter.
+	Max time.Duration
+	// MaxByKind overrides Max for single kinds, e.g. Application.
+	MaxByKind map[string]time.Duration
+}
+
+// For returns the maximum delay for resources of the given kind.
+func (o Options) For(kind string) time.Duration {
+	if d, ok := o.MaxByKind[kind]; ok {
+		return d
+	}
+	return o.Max
+}
+
+// ParseMaxByKind parses durations by kind, e.g. Application=30s, as given on
+// the command line.
+func ParseMaxByKind(in map[string]string) (map[string]time.Duration, error) {
+	out := make(map[string]time.Duration, len(in))
+	for kind, v := range in {
+		d, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtParseMaxByKind, v, kind)
+		}
+		out[kind] = d
+	}
+	return out, nil
+}
+
+// NewEventHandler returns the event handler of a controller. It enqueues the
+// resource of an event like the handler.EnqueueRequestForObject, but delays
+// the first reconcile of a resource by a random duration up to max, see
+// Options.For. Resources that are created at once, e.g. by a Composition, or
+// that are listed when the provider starts are thus not all reconciled at the
+// same time.
+func NewEventHandler(max time.Duration) handler.EventHandler {
+	if max <= 0 {
+		return &handler.EnqueueRequestForObject{}
+	}
+	return &eventHandler{max: max, random: rand.Int63n} // nolint:gosec // jitter needs no secure randomness
+}
+
+type eventHandler struct {
+	handler.EnqueueRequestForObject
+	max time.Duration
+	// random returns a random number in [0, n).
+	random func(n int64) int64
+}
+
+// Create enqueues the created resource after a random delay.
+func (h *eventHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	if evt.Object == nil {
+		h.EnqueueRequestForObject.Create(evt, q)
+		return
+	}
+	q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{
+		Name:      evt.Object.GetName(),
+		Namespace: evt.Object.GetNamespace(),
+	}}, time.Duration(h.random(int64(h.max))))
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jitter
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// queue records the delays of the items added to it.
+type queue struct {
+	workqueue.RateLimitingInterface
+	delays []time.Duration
+}
+
+func (q *queue) Add(_ interface{}) { q.delays = append(q.delays, 0) }
+
+func (q *queue) AddAfter(_ interface{}, d time.Duration) { q.delays = append(q.delays, d) }
+
+func TestOptionsFor(t *testing.T) {
+	o := Options{Max: 10 * time.Second, MaxByKind: map[string]time.Duration{"Application": time.Minute, "Cluster": 0}}
+
+	cases := map[string]time.Duration{
+		"Application": time.Minute,
+		"Cluster":     0,
+		"Project":     10 * time.Second,
+	}
+	for kind, want := range cases {
+		if got := o.For(kind); got != want {
+			t.Errorf("For(%q): want %v, got %v", kind, want, got)
+		}
+	}
+}
+
+func TestParseMaxByKind(t *testing.T) {
+	type want struct {
+		o   map[string]time.Duration
+		err error
+	}
+
+	_, errParse := time.ParseDuration("soon")
+	cases := map[string]struct {
+		in   map[string]string
+		want want
+	}{
+		"Valid": {
+			in:   map[string]string{"Application": "1m", "Project": " 30s"},
+			want: want{o: map[string]time.Duration{"Application": time.Minute, "Project": 30 * time.Second}},
+		},
+		"Invalid": {
+			in:   map[string]string{"Application": "soon"},
+			want: want{err: errors.Wrapf(errParse, errFmtParseMaxByKind, "soon", "Application")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseMaxByKind(tc.in)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseMaxByKind(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("ParseMaxByKind(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewEventHandler(t *testing.T) {
+	o := Options{MaxByKind: map[string]time.Duration{"Application": time.Minute}}
+	if _, ok := NewEventHandler(o.For("Project")).(*handler.EnqueueRequestForObject); !ok {
+		t.Errorf("NewEventHandler(...): want no jitter for a kind without maximum")
+	}
+	if h, ok := NewEventHandler(o.For("Application")).(*eventHandler); !ok || h.max != time.Minute {
+		t.Errorf("NewEventHandler(...): want jitter of up to 1m, got %#v", h)
+	}
+}
+
+func TestEventHandler(t *testing.T) {
+	const (
+		max     = 10 * time.Second
+		events  = 1000
+		buckets = 10
+	)
+	h := &eventHandler{max: max, random: rand.New(rand.NewSource(1)).Int63n} // nolint:gosec // jitter needs no secure randomness
+	q := &queue{}
+
+	for i := 0; i < events; i++ {
+		mg := &fake.Managed{}
+		mg.SetName(fmt.Sprintf("resource-%d", i))
+		h.Create(event.CreateEvent{Object: mg}, q)
+	}
+
+	// All first reconciles start within the window, spread across it.
+	counts := make([]int, buckets)
+	for _, d := range q.delays {
+		if d < 0 || d >= max {
+			t.Fatalf("Create(...): delay %v is not within [0, %v)", d, max)
+		}
+		counts[int(d*buckets/max)]++
+	}
+	for i, c := range counts {
+		if c < events/buckets/2 {
+			t.Errorf("Create(...): only %d of %d delays in [%v, %v)", c, events, max*time.Duration(i)/buckets, max*time.Duration(i+1)/buckets)
+		}
+	}
+
+	// Changes of existing resources are reconciled immediately.
+	q.delays = nil
+	mg := &fake.Managed{}
+	h.Update(event.UpdateEvent{ObjectOld: mg, ObjectNew: mg}, q)
+	if diff := cmp.Diff([]time.Duration{0}, q.delays); diff != "" {
+		t.Errorf("Update(...): -want delays, +got delays:\n%s", diff)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains the Prometheus metrics exposed by the provider.
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+var driftedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "provider_argocd",
+	Name:      "managed_resources_drifted",
+	Help:      "Number of managed resources whose external resource is not up to date.",
+}, []string{"kind", "providerconfig"})
+
+var defaultRecorder = NewDriftRecorder(driftedResources)
+
+func init() {
+	metrics.Registry.MustRegister(driftedResources)
+}
+
+// A DriftRecorder tracks which managed resources are out of sync and
+// reflects their number in a gauge labeled by kind and ProviderConfig. The
+// series of a kind and ProviderConfig is deleted once none of its resources
+// is drifted, so that no series is left behind for a deleted ProviderConfig.
+type DriftRecorder struct {
+	gauge *prometheus.GaugeVec
+
+	mu      sync.Mutex
+	drifted map[string]string
+	counts  map[[2]string]int
+}
+
+// NewDriftRecorder returns a DriftRecorder updating the supplied gauge.
+func NewDriftRecorder(gauge *prometheus.GaugeVec) *DriftRecorder {
+	return &DriftRecorder{gauge: gauge, drifted: map[string]string{}, counts: map[[2]string]int{}}
+}
+
+// Record whether the supplied managed resource of the given kind is drifted.
+func (r *DriftRecorder) Record(kind string, mg resource.Managed, drifted bool) {
+	key := kind + "/" + mg.GetName()
+	pc := ""
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		pc = ref.Name
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, wasDrifted := r.drifted[key]
+	switch {
+	case drifted && wasDrifted && old == pc:
+		return
+	case drifted:
+		if wasDrifted {
+			r.dec(kind, old)
+		}
+		r.drifted[key] = pc
+		r.counts[[2]string{kind, pc}]++
+		r.gauge.WithLabelValues(kind, pc).Inc()
+	case wasDrifted:
+		delete(r.drifted, key)
+		r.dec(kind, old)
+	}
+}
+
+// dec decrements the gauge of the supplied kind and ProviderConfig, and
+// deletes its series once no resource is drifted.
+func (r *DriftRecorder) dec(kind, pc string) {
+	k := [2]string{kind, pc}
+	r.counts[k]--
+	if r.counts[k] > 0 {
+		r.gauge.WithLabelValues(kind, pc).Dec()
+		return
+	}
+	delete(r.counts, k)
+	r.gauge.DeleteLabelValues(kind, pc)
+}
+
+// NewDriftConnecter wraps the supplied connecter, so that the Observe results
+// of its clients are recorded as drift of managed resources of the given kind.
+func NewDriftConnecter(kind string, c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &driftConnecter{kind: kind, connecter: c, recorder: defaultRecorder}
+}
+
+type driftConnecter struct {
+	kind      string
+	connecter managed.ExternalConnecter
+	recorder  *DriftRecorder
+}
+
+func (c *driftConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.connecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &driftClient{ExternalClient: ec, kind: c.kind, recorder: c.recorder}, nil
+}
+
+type driftClient struct {
+	managed.ExternalClient
+	kind     string
+	recorder *DriftRecorder
+}
+
+func (c *driftClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		return o, err
+	}
+	// A deleted resource is no longer reconciled once it is gone, whether
+	// its external resource is deleted, orphaned or released, so its drift
+	// is no longer tracked.
+	c.recorder.Record(c.kind, mg, o.ResourceExists && !o.ResourceUpToDate && !meta.WasDeleted(mg))
+	return o, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+const testKind = "Project"
+
+func newManaged(name, pc string) *fake.Managed {
+	mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: pc}}}
+	mg.SetName(name)
+	return mg
+}
+
+func TestDriftRecorder(t *testing.T) {
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "drifted"}, []string{"kind", "providerconfig"})
+
+	var observation managed.ExternalObservation
+	c := &driftConnecter{
+		kind: testKind,
+		connecter: managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+			return &managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					return observation, nil
+				},
+			}, nil
+		}),
+		recorder: NewDriftRecorder(gauge),
+	}
+
+	a := newManaged("a", "default")
+	b := newManaged("b", "default")
+	// A deleted resource whose external resource is orphaned still exists,
+	// but it is no longer reconciled once its finalizer is removed.
+	orphaned := newManaged("orphaned", "default")
+	orphaned.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	aOrphaned := newManaged("a", "default")
+	aOrphaned.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+
+	steps := []struct {
+		name        string
+		mg          resource.Managed
+		observation managed.ExternalObservation
+		want        float64
+	}{
+		{name: "UpToDate", mg: a, observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, want: 0},
+		{name: "ADrifts", mg: a, observation: managed.ExternalObservation{ResourceExists: true}, want: 1},
+		{name: "AStillDrifted", mg: a, observation: managed.ExternalObservation{ResourceExists: true}, want: 1},
+		{name: "BDrifts", mg: b, observation: managed.ExternalObservation{ResourceExists: true}, want: 2},
+		{name: "AReconciled", mg: a, observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, want: 1},
+		{name: "BDeleted", mg: b, observation: managed.ExternalObservation{}, want: 0},
+		{name: "ADriftsAgain", mg: a, observation: managed.ExternalObservation{ResourceExists: true}, want: 1},
+		{name: "OrphanedNotTracked", mg: orphaned, observation: managed.ExternalObservation{ResourceExists: true}, want: 1},
+		{name: "AOrphaned", mg: aOrphaned, observation: managed.ExternalObservation{ResourceExists: true}, want: 0},
+	}
+
+	for _, s := range steps {
+		observation = s.observation
+		ec, err := c.Connect(context.Background(), s.mg)
+		if err != nil {
+			t.Fatalf("%s: Connect(...): %v", s.name, err)
+		}
+		if _, err := ec.Observe(context.Background(), s.mg); err != nil {
+			t.Fatalf("%s: Observe(...): %v", s.name, err)
+		}
+		if s.want == 0 {
+			if got := testutil.CollectAndCount(gauge); got != 0 {
+				t.Errorf("%s: series: want 0, got %d", s.name, got)
+			}
+			continue
+		}
+		if got := testutil.ToFloat64(gauge.WithLabelValues(testKind, "default")); got != s.want {
+			t.Errorf("%s: drifted resources: want %v, got %v", s.name, s.want, got)
+		}
+	}
+}
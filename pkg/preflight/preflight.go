@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preflight validates a ProviderConfig by connecting to the argocd
+// API it points to.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/version"
+)
+
+// A Step of the preflight check.
+type Step string
+
+// Steps of the preflight check, in the order they are run.
+const (
+	StepProviderConfig Step = "providerconfig"
+	StepAddress        Step = "address"
+	StepAuth           Step = "auth"
+	StepTLS            Step = "tls"
+	StepConnectivity   Step = "connectivity"
+)
+
+const (
+	errGetProviderConfig = "cannot get ProviderConfig"
+	errNoServerAddr      = "serverAddr is not set"
+	errNewClient         = "cannot create argocd client"
+	errVersion           = "cannot get argocd version"
+)
+
+// Result of a preflight check. Step and Err are only set if the check failed.
+type Result struct {
+	Step    Step
+	Err     error
+	Version string
+}
+
+// Passed returns true if the preflight check succeeded.
+func (r Result) Passed() bool {
+	return r.Err == nil
+}
+
+// String returns a human readable summary of the result.
+func (r Result) String() string {
+	if r.Passed() {
+		return fmt.Sprintf("PASS: connected to argocd %s", r.Version)
+	}
+	return fmt.Sprintf("FAIL (%s): %s", r.Step, r.Err)
+}
+
+// A Checker validates ProviderConfigs.
+type Checker struct {
+	kube               client.Client
+	caBundle           *clients.CABundle
+	newVersionClientFn func(clientOpts *apiclient.ClientOptions) (version.ServiceClient, error)
+}
+
+// NewChecker returns a Checker reading ProviderConfigs with the supplied
+// client. It trusts the supplied CA bundle, which may be nil.
+func NewChecker(kube client.Client, b *clients.CABundle) *Checker {
+	return &Checker{kube: kube, caBundle: b, newVersionClientFn: version.NewVersionServiceClient}
+}
+
+// Check resolves the address and credentials of the named ProviderConfig and
+// queries the version of the argocd server it points to.
+func (c *Checker) Check(ctx context.Context, name string) Result {
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		return Result{Step: StepProviderConfig, Err: errors.Wrap(err, errGetProviderConfig)}
+	}
+	if pc.Spec.ServerAddr == "" && pc.Spec.Credentials.Source != v1alpha1.CredentialsSourceConnectionSecret {
+		return Result{Step: StepAddress, Err: errors.New(errNoServerAddr)}
+	}
+
+	opts, err := clients.ClientOptionsFor(ctx, c.kube, pc, c.caBundle)
+	if err != nil {
+		return Result{Step: StepAuth, Err: err}
+	}
+	if opts.ServerAddr == "" {
+		return Result{Step: StepAddress, Err: errors.New(errNoServerAddr)}
+	}
+
+	vc, err := c.newVersionClientFn(opts)
+	if err != nil {
+		return Result{Step: StepConnectivity, Err: errors.Wrap(err, errNewClient)}
+	}
+	v, err := vc.Version(ctx, &emptypb.Empty{})
+	if err != nil {
+		return Result{Step: classify(err), Err: errors.Wrap(err, errVersion)}
+	}
+	return Result{Version: v.Version}
+}
+
+// classify maps an error returned by the argocd API to the failing step.
+func classify(err error) Step {
+	switch status.Code(err) { //nolint:exhaustive
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return StepAuth
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "x509") || strings.Contains(msg, "tls:") {
+		return StepTLS
+	}
+	return StepConnectivity
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	argocdVersion "github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-argocd/apis/v1alpha1"
+	mockclient "github.com/crossplane-contrib/provider-argocd/pkg/clients/mock/version"
+	"github.com/crossplane-contrib/provider-argocd/pkg/clients/version"
+)
+
+var (
+	errBoom        = errors.New("boom")
+	errX509        = errors.New("x509: certificate signed by unknown authority")
+	errUnavailable = status.Error(codes.Unavailable, "connection refused")
+	errUnauth      = status.Error(codes.Unauthenticated, "invalid session")
+	testServerAddr = "argocd-server.argocd.svc:443"
+	testVersion    = "v2.8.4"
+)
+
+type mockModifier func(*mockclient.MockServiceClient)
+
+func withMockClient(t *testing.T, mod mockModifier) *mockclient.MockServiceClient {
+	ctrl := gomock.NewController(t)
+	mock := mockclient.NewMockServiceClient(ctrl)
+	mod(mock)
+	return mock
+}
+
+func withProviderConfig(spec v1alpha1.ProviderConfigSpec) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		switch o := obj.(type) {
+		case *v1alpha1.ProviderConfig:
+			o.Spec = spec
+		case *corev1.Secret:
+			o.Data = map[string][]byte{"token": []byte("s3cr3t")}
+		}
+		return nil
+	}
+}
+
+var secretCredentials = v1alpha1.ProviderCredentials{
+	Source: xpv1.CredentialsSourceSecret,
+	CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+		SecretRef: &xpv1.SecretKeySelector{Key: "token"},
+	},
+}
+
+func TestCheck(t *testing.T) {
+	type args struct {
+		get    test.MockGetFn
+		client version.ServiceClient
+		newErr error
+	}
+
+	cases := map[string]struct {
+		args
+		want Result
+	}{
+		"Passed": {
+			args: args{
+				get: withProviderConfig(v1alpha1.ProviderConfigSpec{ServerAddr: testServerAddr, Credentials: secretCredentials}),
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Version(context.Background(), &emptypb.Empty{}).
+						Return(&argocdVersion.VersionMessage{Version: testVersion}, nil)
+				}),
+			},
+			want: Result{Version: testVersion},
+		},
+		"ProviderConfigNotFound": {
+			args: args{
+				get: test.NewMockGetFn(errBoom),
+			},
+			want: Result{Step: StepProviderConfig, Err: errors.Wrap(errBoom, errGetProviderConfig)},
+		},
+		"NoServerAddr": {
+			args: args{
+				get: withProviderConfig(v1alpha1.ProviderConfigSpec{Credentials: secretCredentials}),
+			},
+			want: Result{Step: StepAddress, Err: errors.New(errNoServerAddr)},
+		},
+		"MissingCredentials": {
+			args: args{
+				get: withProviderConfig(v1alpha1.ProviderConfigSpec{
+					ServerAddr:  testServerAddr,
+					Credentials: v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret},
+				}),
+			},
+			want: Result{Step: StepAuth, Err: errors.New("no credentials secret referenced")},
+		},
+		"Unauthenticated": {
+			args: args{
+				get: withProviderConfig(v1alpha1.ProviderConfigSpec{ServerAddr: testServerAddr, Credentials: secretCredentials}),
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Version(context.Background(), &emptypb.Empty{}).Return(nil, errUnauth)
+				}),
+			},
+			want: Result{Step: StepAuth, Err: errors.Wrap(errUnauth, errVersion)},
+		},
+		"TLS": {
+			args: args{
+				get: withProviderConfig(v1alpha1.ProviderConfigSpec{ServerAddr: testServerAddr, Credentials: secretCredentials}),
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Version(context.Background(), &emptypb.Empty{}).Return(nil, errX509)
+				}),
+			},
+			want: Result{Step: StepTLS, Err: errors.Wrap(errX509, errVersion)},
+		},
+		"Unavailable": {
+			args: args{
+				get: withProviderConfig(v1alpha1.ProviderConfigSpec{ServerAddr: testServerAddr, Credentials: secretCredentials}),
+				client: withMockClient(t, func(mcs *mockclient.MockServiceClient) {
+					mcs.EXPECT().Version(context.Background(), &emptypb.Empty{}).Return(nil, errUnavailable)
+				}),
+			},
+			want: Result{Step: StepConnectivity, Err: errors.Wrap(errUnavailable, errVersion)},
+		},
+		"NewClientFailed": {
+			args: args{
+				get:    withProviderConfig(v1alpha1.ProviderConfigSpec{ServerAddr: testServerAddr, Credentials: secretCredentials}),
+				newErr: errBoom,
+			},
+			want: Result{Step: StepConnectivity, Err: errors.Wrap(errBoom, errNewClient)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &Checker{
+				kube: &test.MockClient{MockGet: tc.args.get},
+				newVersionClientFn: func(_ *apiclient.ClientOptions) (version.ServiceClient, error) {
+					return tc.args.client, tc.args.newErr
+				},
+			}
+			got := c.Check(context.Background(), "default")
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
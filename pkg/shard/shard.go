@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shard partitions the managed resources between provider instances.
+package shard
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const errParseSelector = "cannot parse shard label selector"
+
+// Parse parses the label selector of the managed resources reconciled by a
+// provider instance, e.g. shard=eu. An empty selector selects all resources.
+func Parse(s string) (labels.Selector, error) {
+	sel, err := labels.Parse(s)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseSelector)
+	}
+	return sel, nil
+}
+
+// LeaderElectionID returns the leader election ID of the provider instances
+// of the shard with the supplied selector. Instances of different shards
+// must not compete for the same lease, so the ID is suffixed with a hash of
+// the selector unless all resources are selected.
+func LeaderElectionID(base string, sel labels.Selector) string {
+	if sel == nil || sel.Empty() {
+		return base
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(sel.String()))
+	return fmt.Sprintf("%s-%08x", base, h.Sum32())
+}
+
+// NewPredicate returns the predicate of the controller watches. It drops the
+// events of resources that do not match the supplied selector, so that
+// several provider instances can each reconcile a part of the resources. A
+// nil selector selects all resources. A resource whose labels are changed to
+// match another shard is picked up by that shard's update event.
+func NewPredicate(sel labels.Selector) predicate.Predicate {
+	if sel == nil {
+		sel = labels.Everything()
+	}
+	return predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return sel.Matches(labels.Set(o.GetLabels()))
+	})
+}
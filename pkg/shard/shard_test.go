@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func withLabels(l map[string]string) *fake.Managed {
+	mg := &fake.Managed{}
+	mg.SetLabels(l)
+	return mg
+}
+
+func TestPredicate(t *testing.T) {
+	cases := map[string]struct {
+		selector string
+		labels   map[string]string
+		want     bool
+	}{
+		"NoSelector": {
+			labels: map[string]string{"shard": "us"},
+			want:   true,
+		},
+		"Matching": {
+			selector: "shard=eu",
+			labels:   map[string]string{"shard": "eu"},
+			want:     true,
+		},
+		"NotMatching": {
+			selector: "shard=eu",
+			labels:   map[string]string{"shard": "us"},
+			want:     false,
+		},
+		"Unlabeled": {
+			selector: "shard=eu",
+			want:     false,
+		},
+		"NotInSet": {
+			selector: "shard notin (eu)",
+			want:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sel, err := Parse(tc.selector)
+			if err != nil {
+				t.Fatalf("Parse(...): %v", err)
+			}
+
+			p := NewPredicate(sel)
+			mg := withLabels(tc.labels)
+			if got := p.Create(event.CreateEvent{Object: mg}); got != tc.want {
+				t.Errorf("Create(...): want %t, got %t", tc.want, got)
+			}
+			if got := p.Update(event.UpdateEvent{ObjectOld: mg, ObjectNew: mg}); got != tc.want {
+				t.Errorf("Update(...): want %t, got %t", tc.want, got)
+			}
+			if got := p.Delete(event.DeleteEvent{Object: mg}); got != tc.want {
+				t.Errorf("Delete(...): want %t, got %t", tc.want, got)
+			}
+			if got := p.Generic(event.GenericEvent{Object: mg}); got != tc.want {
+				t.Errorf("Generic(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseInvalidSelector(t *testing.T) {
+	if _, err := Parse("shard in eu"); err == nil {
+		t.Fatal("Parse(...): want error, got none")
+	}
+}
+
+func TestNilPredicate(t *testing.T) {
+	if !NewPredicate(nil).Create(event.CreateEvent{Object: withLabels(map[string]string{"shard": "us"})}) {
+		t.Error("Create(...): want true, got false")
+	}
+}
+
+func TestLeaderElectionID(t *testing.T) {
+	const base = "crossplane-leader-election-provider-argocd"
+
+	ids := map[string]string{}
+	for _, s := range []string{"", "shard=eu", "shard=us"} {
+		sel, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(...): %v", err)
+		}
+		ids[s] = LeaderElectionID(base, sel)
+	}
+	if ids[""] != base {
+		t.Errorf("LeaderElectionID(...): want %q without a selector, got %q", base, ids[""])
+	}
+	if ids["shard=eu"] == base || ids["shard=eu"] == ids["shard=us"] {
+		t.Errorf("LeaderElectionID(...): want distinct IDs per shard, got %v", ids)
+	}
+}
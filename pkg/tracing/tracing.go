@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing contains the OpenTelemetry tracing of the provider.
+package tracing
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	serviceName = "provider-argocd"
+	tracerName  = "github.com/crossplane-contrib/provider-argocd"
+
+	errCreateExporter = "cannot create OTLP trace exporter"
+)
+
+// Options configure the export of traces.
+type Options struct {
+	// Endpoint is the host:port of the OTLP gRPC receiver traces are exported
+	// to. Tracing is disabled if it is empty.
+	Endpoint string
+	// Insecure disables TLS for the connection to the receiver.
+	Insecure bool
+}
+
+// Setup exports the traces of the provider as configured by the supplied
+// options. It returns a function that flushes and stops the export. Setup does
+// nothing if no endpoint is given, so that spans are not recorded at all.
+func Setup(ctx context.Context, o Options) (func(context.Context) error, error) {
+	if o.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(o.Endpoint)}
+	if o.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exp, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateExporter)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// UnaryClientInterceptor returns an interceptor that records a span for every
+// call to the argocd API, see clients.WithUnaryInterceptors.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return unaryClientInterceptor(otel.Tracer(tracerName))
+}
+
+func unaryClientInterceptor(t trace.Tracer) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := t.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.RPCSystemGRPC, attribute.String("rpc.method", method)))
+		defer span.End()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		recordError(span, err)
+		return err
+	}
+}
+
+// NewConnecter wraps the supplied connecter, so that connecting to and
+// observing, creating, updating and deleting external resources of the given
+// kind is recorded as spans.
+func NewConnecter(kind string, c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &connecter{kind: kind, connecter: c, tracer: otel.Tracer(tracerName)}
+}
+
+type connecter struct {
+	kind      string
+	connecter managed.ExternalConnecter
+	tracer    trace.Tracer
+}
+
+func (c *connecter) Connect(ctx context.Context, mg xpresource.Managed) (managed.ExternalClient, error) {
+	ctx, span := start(ctx, c.tracer, c.kind, "Connect", mg)
+	defer span.End()
+	ec, err := c.connecter.Connect(ctx, mg)
+	recordError(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return &client{client: ec, kind: c.kind, tracer: c.tracer}, nil
+}
+
+type client struct {
+	client managed.ExternalClient
+	kind   string
+	tracer trace.Tracer
+}
+
+func (c *client) Observe(ctx context.Context, mg xpresource.Managed) (managed.ExternalObservation, error) {
+	ctx, span := start(ctx, c.tracer, c.kind, "Observe", mg)
+	defer span.End()
+	o, err := c.client.Observe(ctx, mg)
+	span.SetAttributes(
+		attribute.Bool("resource.exists", o.ResourceExists),
+		attribute.Bool("resource.up_to_date", o.ResourceUpToDate),
+	)
+	recordError(span, err)
+	return o, err
+}
+
+func (c *client) Create(ctx context.Context, mg xpresource.Managed) (managed.ExternalCreation, error) {
+	ctx, span := start(ctx, c.tracer, c.kind, "Create", mg)
+	defer span.End()
+	cr, err := c.client.Create(ctx, mg)
+	recordError(span, err)
+	return cr, err
+}
+
+func (c *client) Update(ctx context.Context, mg xpresource.Managed) (managed.ExternalUpdate, error) {
+	ctx, span := start(ctx, c.tracer, c.kind, "Update", mg)
+	defer span.End()
+	u, err := c.client.Update(ctx, mg)
+	recordError(span, err)
+	return u, err
+}
+
+func (c *client) Delete(ctx context.Context, mg xpresource.Managed) error {
+	ctx, span := start(ctx, c.tracer, c.kind, "Delete", mg)
+	defer span.End()
+	err := c.client.Delete(ctx, mg)
+	recordError(span, err)
+	return err
+}
+
+// start starts a span named <kind>.<operation> for the supplied managed
+// resource.
+func start(ctx context.Context, t trace.Tracer, kind, operation string, mg xpresource.Managed) (context.Context, trace.Span) {
+	return t.Start(ctx, kind+"."+operation, trace.WithAttributes(
+		attribute.String("crossplane.kind", kind),
+		attribute.String("crossplane.name", mg.GetName()),
+		attribute.String("crossplane.external_name", meta.GetExternalName(mg)),
+	))
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
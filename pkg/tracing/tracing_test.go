@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+const testKind = "Project"
+
+var errBoom = errors.New("boom")
+
+type span struct {
+	Name  string
+	Code  codes.Code
+	Attrs map[string]string
+}
+
+func spans(sr *tracetest.SpanRecorder) []span {
+	var res []span
+	for _, s := range sr.Ended() {
+		attrs := map[string]string{}
+		for _, a := range s.Attributes() {
+			attrs[string(a.Key)] = a.Value.Emit()
+		}
+		res = append(res, span{Name: s.Name(), Code: s.Status().Code, Attrs: attrs})
+	}
+	return res
+}
+
+func TestConnecter(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	c := &connecter{
+		kind: testKind,
+		connecter: managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+			return &managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{ResourceExists: true}, nil
+				},
+				UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+					return managed.ExternalUpdate{}, errBoom
+				},
+			}, nil
+		}),
+		tracer: tp.Tracer("test"),
+	}
+
+	mg := &fake.Managed{}
+	mg.SetName("example")
+	meta.SetExternalName(mg, "example-project")
+
+	ec, err := c.Connect(context.Background(), mg)
+	if err != nil {
+		t.Fatalf("Connect(...): %v", err)
+	}
+	if _, err := ec.Observe(context.Background(), mg); err != nil {
+		t.Fatalf("Observe(...): %v", err)
+	}
+	if _, err := ec.Update(context.Background(), mg); !errors.Is(err, errBoom) {
+		t.Fatalf("Update(...): want %v, got %v", errBoom, err)
+	}
+
+	resourceAttrs := map[string]string{
+		"crossplane.kind":          testKind,
+		"crossplane.name":          "example",
+		"crossplane.external_name": "example-project",
+	}
+	observeAttrs := map[string]string{"resource.exists": "true", "resource.up_to_date": "false"}
+	for k, v := range resourceAttrs {
+		observeAttrs[k] = v
+	}
+	want := []span{
+		{Name: "Project.Connect", Code: codes.Unset, Attrs: resourceAttrs},
+		{Name: "Project.Observe", Code: codes.Unset, Attrs: observeAttrs},
+		{Name: "Project.Update", Code: codes.Error, Attrs: resourceAttrs},
+	}
+	if diff := cmp.Diff(want, spans(sr)); diff != "" {
+		t.Errorf("spans: -want, +got:\n%s", diff)
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	type want struct {
+		err  error
+		code codes.Code
+	}
+
+	cases := map[string]struct {
+		err  error
+		want want
+	}{
+		"Success": {
+			want: want{code: codes.Unset},
+		},
+		"Error": {
+			err:  errBoom,
+			want: want{err: errBoom, code: codes.Error},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			i := unaryClientInterceptor(tp.Tracer("test"))
+
+			method := "/project.ProjectService/Get"
+			invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				return tc.err
+			}
+			if err := i(context.Background(), method, nil, nil, nil, invoker); !errors.Is(err, tc.want.err) {
+				t.Errorf("i(...): want error %v, got %v", tc.want.err, err)
+			}
+			got := spans(sr)
+			if len(got) != 1 {
+				t.Fatalf("want 1 span, got %d", len(got))
+			}
+			if got[0].Name != method {
+				t.Errorf("span name: want %q, got %q", method, got[0].Name)
+			}
+			if got[0].Code != tc.want.code {
+				t.Errorf("span status: want %v, got %v", tc.want.code, got[0].Code)
+			}
+		})
+	}
+}